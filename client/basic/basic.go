@@ -1,14 +1,20 @@
 package basic
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	"gopkg.in/resty.v1"
 
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+	"github.com/bnb-chain/go-sdk/common"
 	"github.com/bnb-chain/go-sdk/types"
 	"github.com/bnb-chain/go-sdk/types/tx"
 	"github.com/gorilla/websocket"
@@ -21,9 +27,13 @@ const (
 type BasicClient interface {
 	Get(path string, qp map[string]string) ([]byte, int, error)
 	Post(path string, body interface{}, param map[string]string) ([]byte, error)
+	GetCtx(ctx context.Context, path string, qp map[string]string) ([]byte, int, error)
+	PostCtx(ctx context.Context, path string, body interface{}, param map[string]string) ([]byte, error)
 
 	GetTx(txHash string) (*tx.TxResult, error)
 	PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error)
+	GetTxCtx(ctx context.Context, txHash string) (*tx.TxResult, error)
+	PostTxCtx(ctx context.Context, hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error)
 	WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (<-chan interface{}, error)
 }
 
@@ -31,55 +41,219 @@ type client struct {
 	baseUrl string
 	apiUrl  string
 	apiKey  string
+
+	transport        *common.TransportConfig
+	interceptor      interceptor.Interceptor
+	http             *resty.Client
+	limiter          *tokenBucket
+	rateLimitRetries int
+	maxRetryAfter    time.Duration
+	// err is the error of NewClient, returned by every request
+	err error
 }
 
-func NewClient(baseUrl string, apiKey string) BasicClient {
-	return &client{baseUrl: baseUrl, apiUrl: fmt.Sprintf("%s://%s", types.DefaultApiSchema, baseUrl+types.DefaultAPIVersionPrefix), apiKey: apiKey}
+// Option configures a client created by NewClient.
+type Option func(*client)
+
+// WithRateLimit limits the client to requestsPerSecond, allowing bursts of burst requests.
+// Every endpoint has its own limit, requests are not limited by default.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *client) {
+		c.limiter = newTokenBucket(requestsPerSecond, burst)
+	}
 }
 
-func (c *client) Get(path string, qp map[string]string) ([]byte, int, error) {
-	request := resty.R().SetQueryParams(qp)
-	if c.apiKey != "" {
-		request.SetHeader("apikey", c.apiKey)
+// WithRateLimitRetries sets how many times a request answered with 429 is sent again,
+// and the longest Retry-After delay worth waiting for. It defaults to 3 retries within 30s.
+func WithRateLimitRetries(maxRetries int, maxRetryAfter time.Duration) Option {
+	return func(c *client) {
+		c.rateLimitRetries, c.maxRetryAfter = maxRetries, maxRetryAfter
+	}
+}
+
+// WithTransport sets the HTTP client, TLS config, headers, scheme or proxy used to reach
+// the endpoint, for requests as well as websockets.
+func WithTransport(options ...common.TransportOption) Option {
+	return func(c *client) {
+		for _, option := range options {
+			option(c.transport)
+		}
 	}
-	resp, err := request.Get(c.apiUrl + path)
+}
+
+// WithInterceptors observes every request with interceptors, the first one being the outermost.
+func WithInterceptors(interceptors ...interceptor.Interceptor) Option {
+	return func(c *client) {
+		c.interceptor = interceptor.Chain(interceptors...)
+	}
+}
+
+// NewClient returns a client of the API at baseUrl. When the transport options can't be
+// applied, e.g. a proxy with an HTTP client not using an *http.Transport, every request
+// fails with the error.
+func NewClient(baseUrl string, apiKey string, options ...Option) BasicClient {
+	c, err := newClient(baseUrl, apiKey, options...)
 	if err != nil {
-		return nil, 0, err
+		return &client{baseUrl: baseUrl, err: err}
+	}
+	return c
+}
+
+func newClient(baseUrl string, apiKey string, options ...Option) (*client, error) {
+	c := &client{
+		baseUrl:          baseUrl,
+		apiKey:           apiKey,
+		transport:        common.NewTransportConfig(),
+		limiter:          newTokenBucket(0, 1),
+		rateLimitRetries: defaultRateLimitRetries,
+		maxRetryAfter:    defaultMaxRetryAfter,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	scheme := types.DefaultApiSchema
+	if c.transport.Scheme != "" {
+		scheme = c.transport.Scheme
+	}
+	c.apiUrl = fmt.Sprintf("%s://%s", scheme, baseUrl+types.DefaultAPIVersionPrefix)
+
+	if c.transport.HTTPClient != nil {
+		// resty changes the client it is given, so it gets a copy
+		hc, err := c.transport.NewHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		c.http = resty.NewWithClient(hc)
+	} else {
+		c.http = resty.New().SetRedirectPolicy(resty.FlexibleRedirectPolicy(10))
+		if c.transport.TLSConfig != nil {
+			c.http.SetTLSClientConfig(c.transport.TLSConfig)
+		}
+		if c.transport.Proxy != nil {
+			c.http.SetProxy(c.transport.Proxy.String())
+		}
 	}
-	if resp.StatusCode() >= http.StatusMultipleChoices || resp.StatusCode() < http.StatusOK {
-		err = fmt.Errorf("bad response, status code %d, response: %s", resp.StatusCode(), string(resp.Body()))
+	if len(c.transport.Headers) > 0 {
+		c.http.SetHeaders(c.transport.Headers)
 	}
-	return resp.Body(), resp.StatusCode(), err
+	return c, nil
+}
+
+func (c *client) Get(path string, qp map[string]string) ([]byte, int, error) {
+	return c.GetCtx(context.Background(), path, qp)
+}
+
+func (c *client) GetCtx(ctx context.Context, path string, qp map[string]string) (bz []byte, code int, err error) {
+	err = c.invoke(ctx, resty.MethodGet, path, "", func(ctx context.Context) error {
+		resp, err := c.send(ctx, resty.MethodGet, path, func(request *resty.Request) {
+			request.SetQueryParams(qp)
+		})
+		if err != nil {
+			return err
+		}
+		bz, code = resp.Body(), resp.StatusCode()
+		if code >= http.StatusMultipleChoices || code < http.StatusOK {
+			return newHTTPError(resp)
+		}
+		return nil
+	})
+	return bz, code, err
 }
 
 // Post generic method
 func (c *client) Post(path string, body interface{}, param map[string]string) ([]byte, error) {
-	request := resty.R().
-		SetHeader("Content-Type", "text/plain").
-		SetBody(body).
-		SetQueryParams(param)
-	if c.apiKey != "" {
-		request.SetHeader("apikey", c.apiKey)
+	return c.PostCtx(context.Background(), path, body, param)
+}
+
+func (c *client) PostCtx(ctx context.Context, path string, body interface{}, param map[string]string) ([]byte, error) {
+	return c.post(ctx, path, body, param, "")
+}
+
+func (c *client) post(ctx context.Context, path string, body interface{}, param map[string]string, txHash string) (bz []byte, err error) {
+	err = c.invoke(ctx, resty.MethodPost, path, txHash, func(ctx context.Context) error {
+		resp, err := c.send(ctx, resty.MethodPost, path, func(request *resty.Request) {
+			request.SetHeader("Content-Type", "text/plain").
+				SetBody(body).
+				SetQueryParams(param)
+		})
+		if err != nil {
+			return err
+		}
+		bz = resp.Body()
+		if resp.StatusCode() >= http.StatusMultipleChoices {
+			return newHTTPError(resp)
+		}
+		return nil
+	})
+	return bz, err
+}
+
+func (c *client) invoke(ctx context.Context, method, path, txHash string, send func(ctx context.Context) error) error {
+	if c.err != nil {
+		return c.err
 	}
-	resp, err := request.Post(c.apiUrl + path)
+	call := &interceptor.Call{
+		Client:   interceptor.ClientREST,
+		Method:   method,
+		Route:    interceptor.Route(path),
+		Path:     path,
+		Endpoint: c.baseUrl,
+		TxHash:   txHash,
+	}
+	return interceptor.Invoke(ctx, c.interceptor, call, func(ctx context.Context, _ *interceptor.Call) error {
+		return send(ctx)
+	})
+}
 
-	if err != nil {
-		return nil, err
+// send waits for the rate limiter, then sends the request set up by build. A request
+// answered with 429 was not processed, so it is sent again once Retry-After elapsed,
+// which holds the other requests of the client as well. When it is not retried, the
+// 429 is returned at once and the other requests are not held.
+func (c *client) send(ctx context.Context, method, path string, build func(*resty.Request)) (*resty.Response, error) {
+	for retries := 0; ; retries++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		request := c.http.R().SetContext(ctx)
+		if c.apiKey != "" {
+			request.SetHeader("apikey", c.apiKey)
+		}
+		build(request)
+		resp, err := request.Execute(method, c.apiUrl+path)
+		if err != nil || resp.StatusCode() != http.StatusTooManyRequests {
+			return resp, err
+		}
+		wait := parseRetryAfter(resp.Header())
+		if retries >= c.rateLimitRetries || wait > c.maxRetryAfter {
+			return resp, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, nil
+		}
+		c.limiter.Pause(time.Now().Add(wait))
 	}
-	if resp.StatusCode() >= http.StatusMultipleChoices {
-		err = fmt.Errorf("bad response, status code %d, response: %s", resp.StatusCode(), string(resp.Body()))
+}
+
+func newHTTPError(resp *resty.Response) error {
+	err := tx.NewHTTPError(resp.StatusCode(), resp.Body())
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		err.RetryAfter = parseRetryAfter(resp.Header())
 	}
-	return resp.Body(), err
+	return err
 }
 
 // GetTx returns transaction details
 func (c *client) GetTx(txHash string) (*tx.TxResult, error) {
+	return c.GetTxCtx(context.Background(), txHash)
+}
+
+func (c *client) GetTxCtx(ctx context.Context, txHash string) (*tx.TxResult, error) {
 	if txHash == "" {
 		return nil, fmt.Errorf("Invalid tx hash %s ", txHash)
 	}
 
 	qp := map[string]string{}
-	resp, _, err := c.Get("/tx/"+txHash, qp)
+	resp, _, err := c.GetCtx(ctx, "/tx/"+txHash, qp)
 	if err != nil {
 		return nil, err
 	}
@@ -94,12 +268,20 @@ func (c *client) GetTx(txHash string) (*tx.TxResult, error) {
 
 // PostTx returns transaction details
 func (c *client) PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error) {
+	return c.PostTxCtx(context.Background(), hexTx, param)
+}
+
+func (c *client) PostTxCtx(ctx context.Context, hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error) {
 	if len(hexTx) == 0 {
 		return nil, fmt.Errorf("Invalid tx  %s", hexTx)
 	}
 
 	body := hexTx
-	resp, err := c.Post("/broadcast", body, param)
+	var txHash string
+	if raw, err := hex.DecodeString(string(hexTx)); err == nil {
+		txHash = strings.ToUpper(hex.EncodeToString(tmhash.Sum(raw)))
+	}
+	resp, err := c.post(ctx, "/broadcast", body, param, txHash)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +294,11 @@ func (c *client) PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitRes
 }
 
 func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (<-chan interface{}, error) {
-	u := url.URL{Scheme: types.DefaultWSSchema, Host: c.baseUrl, Path: fmt.Sprintf("%s/%s", types.DefaultWSPrefix, path)}
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if c.err != nil {
+		return nil, c.err
+	}
+	u := url.URL{Scheme: c.transport.WSScheme(types.DefaultWSSchema), Host: c.baseUrl, Path: fmt.Sprintf("%s/%s", types.DefaultWSPrefix, path)}
+	conn, _, err := c.transport.WebsocketDialer().Dial(u.String(), c.transport.HTTPHeader())
 	if err != nil {
 		return nil, err
 	}
@@ -128,8 +313,8 @@ func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, erro
 	keepAliveCh := time.NewTicker(30 * time.Minute)
 	pingTicker := time.NewTicker(10 * time.Second)
 	go func() {
+		// closing the connection stops the reading goroutine, which closes messages
 		defer conn.Close()
-		defer close(messages)
 		defer keepAliveCh.Stop()
 		defer pingTicker.Stop()
 		select {
@@ -140,15 +325,15 @@ func (c *client) WsGet(path string, constructMsg func([]byte) (interface{}, erro
 		}
 	}()
 	go func() {
+		defer close(messages)
 		writeMsg := func(m interface{}) bool {
 			select {
 			case <-closeCh:
 				// already closed by user
 				return true
-			default:
+			case messages <- m:
+				return false
 			}
-			messages <- m
-			return false
 		}
 		for {
 			select {
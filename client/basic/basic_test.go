@@ -0,0 +1,121 @@
+package basic
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/common"
+)
+
+func TestTransportOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/time", r.URL.Path)
+		assert.Equal(t, "go-sdk", r.Header.Get("X-Client"))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	c := NewClient(strings.TrimPrefix(server.URL, "http://"), "", WithTransport(
+		common.WithHTTPClient(server.Client()),
+		common.WithScheme("http"),
+		common.WithHeaders(map[string]string{"X-Client": "go-sdk"}),
+	))
+	bz, code, err := c.Get("/time", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", string(bz))
+}
+
+func TestTransportOptionsCopyClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// the proxy is the server itself, which answers the request
+	proxy, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	tlsConfig := &tls.Config{}
+	tr := &http.Transport{TLSClientConfig: tlsConfig}
+	hc := &http.Client{Transport: tr}
+	c := NewClient("api.example.com", "", WithTransport(
+		common.WithHTTPClient(hc),
+		common.WithScheme("http"),
+		common.WithTLSConfig(&tls.Config{ServerName: "api.example.com"}),
+		common.WithProxy(proxy),
+	))
+	bz, _, err := c.Get("/time", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", string(bz))
+	assert.True(t, tr.TLSClientConfig == tlsConfig)
+	assert.Empty(t, tlsConfig.ServerName)
+	assert.Nil(t, tr.Proxy)
+	assert.Nil(t, hc.CheckRedirect)
+	assert.True(t, hc.Transport == tr)
+}
+
+type wrappedTransport struct {
+	http.RoundTripper
+}
+
+func TestTransportOptionsUnsupported(t *testing.T) {
+	proxy, err := url.Parse("http://127.0.0.1:8080")
+	assert.NoError(t, err)
+	option := WithTransport(
+		common.WithHTTPClient(&http.Client{Transport: wrappedTransport{http.DefaultTransport}}),
+		common.WithProxy(proxy),
+	)
+	_, _, err = NewClient("api.example.com", "", option).Get("/time", nil)
+	assert.EqualError(t, err, "can't set the TLS config or proxy of a basic.wrappedTransport transport")
+	_, err = NewFailoverClient([]string{"api.example.com"}, "", common.RetryOptions{}, option)
+	assert.Error(t, err)
+}
+
+func TestWsGetClose(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/ws/stream", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for i := 0; ; i++ {
+			if err := conn.WriteJSON(WSResponse{Stream: "stream", Data: i}); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(strings.TrimPrefix(server.URL, "http://"), "", WithTransport(common.WithScheme("http")))
+	closeCh := make(chan struct{})
+	messages, err := c.WsGet("stream", func(bz []byte) (interface{}, error) {
+		return string(bz), nil
+	}, closeCh)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", <-messages)
+	// the next message is being sent while the stream is closed, which must not panic
+	time.Sleep(100 * time.Millisecond)
+	close(closeCh)
+	time.Sleep(100 * time.Millisecond)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-messages:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("messages is not closed")
+		}
+	}
+}
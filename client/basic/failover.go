@@ -0,0 +1,129 @@
+package basic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// failoverClient spreads calls over several API endpoints, see NewFailoverClient.
+type failoverClient struct {
+	clients []*client
+	pool    *common.EndpointPool
+}
+
+// NewFailoverClient returns a BasicClient calling the first healthy of baseUrls, each
+// endpoint being set up with options. Get requests are retried on the next endpoint
+// after a transport failure, a 429 or a 5xx response. Post requests, such as broadcasts,
+// only go to the next endpoint when they were not processed: the connection failed or
+// the endpoint answered 429.
+func NewFailoverClient(baseUrls []string, apiKey string, opts common.RetryOptions, options ...Option) (BasicClient, error) {
+	if len(baseUrls) == 0 {
+		return nil, fmt.Errorf("no api endpoint")
+	}
+	f := &failoverClient{clients: make([]*client, 0, len(baseUrls))}
+	for _, baseUrl := range baseUrls {
+		c, err := newClient(baseUrl, apiKey, options...)
+		if err != nil {
+			return nil, err
+		}
+		f.clients = append(f.clients, c)
+	}
+	f.pool = common.NewEndpointPool(baseUrls, opts, f.checkHealth)
+	return f, nil
+}
+
+func (f *failoverClient) checkHealth(ctx context.Context, i int) (int64, bool, error) {
+	bz, _, err := f.clients[i].GetCtx(ctx, "/node-info", map[string]string{})
+	if err != nil {
+		return 0, false, err
+	}
+	var status types.ResultStatus
+	if err := json.Unmarshal(bz, &status); err != nil {
+		return 0, false, err
+	}
+	return status.SyncInfo.LatestBlockHeight, status.SyncInfo.CatchingUp, nil
+}
+
+func (f *failoverClient) Get(path string, qp map[string]string) ([]byte, int, error) {
+	return f.GetCtx(context.Background(), path, qp)
+}
+
+func (f *failoverClient) GetCtx(ctx context.Context, path string, qp map[string]string) (bz []byte, code int, err error) {
+	err = f.pool.Do(ctx, isRetryable, func(i int) (err error) {
+		bz, code, err = f.clients[i].GetCtx(ctx, path, qp)
+		return err
+	})
+	return bz, code, err
+}
+
+func (f *failoverClient) Post(path string, body interface{}, param map[string]string) ([]byte, error) {
+	return f.PostCtx(context.Background(), path, body, param)
+}
+
+func (f *failoverClient) PostCtx(ctx context.Context, path string, body interface{}, param map[string]string) (bz []byte, err error) {
+	err = f.pool.Do(ctx, isNotSent, func(i int) (err error) {
+		bz, err = f.clients[i].PostCtx(ctx, path, body, param)
+		return err
+	})
+	return bz, err
+}
+
+func (f *failoverClient) GetTx(txHash string) (*tx.TxResult, error) {
+	return f.GetTxCtx(context.Background(), txHash)
+}
+
+func (f *failoverClient) GetTxCtx(ctx context.Context, txHash string) (res *tx.TxResult, err error) {
+	err = f.pool.Do(ctx, isRetryable, func(i int) (err error) {
+		res, err = f.clients[i].GetTxCtx(ctx, txHash)
+		return err
+	})
+	return res, err
+}
+
+func (f *failoverClient) PostTx(hexTx []byte, param map[string]string) ([]tx.TxCommitResult, error) {
+	return f.PostTxCtx(context.Background(), hexTx, param)
+}
+
+func (f *failoverClient) PostTxCtx(ctx context.Context, hexTx []byte, param map[string]string) (res []tx.TxCommitResult, err error) {
+	err = f.pool.Do(ctx, isNotSent, func(i int) (err error) {
+		res, err = f.clients[i].PostTxCtx(ctx, hexTx, param)
+		return err
+	})
+	return res, err
+}
+
+func (f *failoverClient) WsGet(path string, constructMsg func([]byte) (interface{}, error), closeCh <-chan struct{}) (ch <-chan interface{}, err error) {
+	err = f.pool.Do(context.Background(), isNotSent, func(i int) (err error) {
+		ch, err = f.clients[i].WsGet(path, constructMsg, closeCh)
+		return err
+	})
+	return ch, err
+}
+
+// isNotSent reports whether err guarantees the request was not processed by the endpoint.
+func isNotSent(err error) bool {
+	var httpErr *tx.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func isRetryable(err error) bool {
+	var httpErr *tx.HTTPError
+	if errors.As(err, &httpErr) {
+		// a 429 still seen here outlasted the retries of the endpoint, try another one
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr)
+}
@@ -0,0 +1,97 @@
+package basic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func newTestClient(server *httptest.Server, options ...Option) *client {
+	options = append(options, WithTransport(common.WithTLSConfig(&tls.Config{InsecureSkipVerify: true})))
+	c, err := newClient(strings.TrimPrefix(server.URL, "https://"), "key", options...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("apikey"))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	bz, code, err := newTestClient(server).Get("/time", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", string(bz))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.True(t, time.Since(start) >= time.Second)
+
+	atomic.StoreInt32(&calls, 0)
+	_, code, err = newTestClient(server, WithRateLimitRetries(0, time.Minute)).Get("/time", nil)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.True(t, errors.Is(err, tx.ErrRateLimited))
+	var httpErr *tx.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, time.Second, httpErr.RetryAfter)
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	var calls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// not retried, so the next requests are not held for an hour
+	c := newTestClient(server)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, code, err := c.GetCtx(ctx, "/time", nil)
+	assert.Equal(t, http.StatusTooManyRequests, code)
+	assert.True(t, errors.Is(err, tx.ErrRateLimited))
+	bz, code, err := c.GetCtx(ctx, "/time", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", string(bz))
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(20, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, b.Wait(context.Background()))
+	}
+	// the burst is free, the next two wait 50ms each
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.Pause(time.Now().Add(time.Minute))
+	assert.Equal(t, context.Canceled, b.Wait(ctx))
+}
@@ -1,11 +1,10 @@
 package client
 
 import (
-	"gopkg.in/resty.v1"
-
 	"github.com/bnb-chain/go-sdk/client/basic"
 	"github.com/bnb-chain/go-sdk/client/query"
 	"github.com/bnb-chain/go-sdk/client/transaction"
+	"github.com/bnb-chain/go-sdk/common"
 	"github.com/bnb-chain/go-sdk/common/types"
 	"github.com/bnb-chain/go-sdk/keys"
 )
@@ -24,13 +23,21 @@ type DexClient interface {
 	transaction.TransactionClient
 }
 
-func init() {
-	resty.DefaultClient.SetRedirectPolicy(resty.FlexibleRedirectPolicy(10))
+func NewDexClientWithApiKey(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager, apiKey string, options ...basic.Option) (DexClient, error) {
+	types.SetNetwork(network)
+	c := basic.NewClient(baseUrl+"/internal", apiKey, options...)
+	q := query.NewClient(c)
+	n, err := q.GetNodeInfo()
+	if err != nil {
+		return nil, err
+	}
+	t := transaction.NewClient(n.NodeInfo.Network, keyManager, q, c)
+	return &dexClient{BasicClient: c, QueryClient: q, TransactionClient: t}, nil
 }
 
-func NewDexClientWithApiKey(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager, apiKey string) (DexClient, error) {
+func NewDexClient(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager, options ...basic.Option) (DexClient, error) {
 	types.SetNetwork(network)
-	c := basic.NewClient(baseUrl+"/internal", apiKey)
+	c := basic.NewClient(baseUrl, "", options...)
 	q := query.NewClient(c)
 	n, err := q.GetNodeInfo()
 	if err != nil {
@@ -40,9 +47,13 @@ func NewDexClientWithApiKey(baseUrl string, network types.ChainNetwork, keyManag
 	return &dexClient{BasicClient: c, QueryClient: q, TransactionClient: t}, nil
 }
 
-func NewDexClient(baseUrl string, network types.ChainNetwork, keyManager keys.KeyManager) (DexClient, error) {
+// NewFailoverDexClient is NewDexClient over several api endpoints, see basic.NewFailoverClient.
+func NewFailoverDexClient(baseUrls []string, network types.ChainNetwork, keyManager keys.KeyManager, opts common.RetryOptions, options ...basic.Option) (DexClient, error) {
 	types.SetNetwork(network)
-	c := basic.NewClient(baseUrl, "")
+	c, err := basic.NewFailoverClient(baseUrls, "", opts, options...)
+	if err != nil {
+		return nil, err
+	}
 	q := query.NewClient(c)
 	n, err := q.GetNodeInfo()
 	if err != nil {
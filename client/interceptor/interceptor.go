@@ -0,0 +1,134 @@
+// Package interceptor observes the calls of the REST and RPC clients. Interceptors
+// are chained around every request and see its method, route, endpoint, latency,
+// error and, for broadcasts, the tx hash.
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+const (
+	ClientREST = "rest"
+	ClientRPC  = "rpc"
+)
+
+// Call describes one request of a client. The RPC queries and broadcasts answered with
+// a non-zero code fail with a *tx.ABCIError within the interceptors, though the client
+// returns them as responses.
+type Call struct {
+	// Client is ClientREST or ClientRPC.
+	Client string
+	// Method is the HTTP method or the JSON-RPC method, e.g. GET or abci_query.
+	Method string
+	// Route is the path without its parameters, e.g. /account or custom/gov. It is
+	// meant as a metric label, unlike Path.
+	Route string
+	// Path is the request path or the ABCI query path.
+	Path     string
+	Endpoint string
+	// TxHash is the hash of the broadcast tx, empty for queries.
+	TxHash string
+}
+
+// Invoker sends the request described by call.
+type Invoker func(ctx context.Context, call *Call) error
+
+// Interceptor wraps a request, calling next to send it.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) error
+
+// Chain returns an interceptor calling interceptors in order, the first one being the
+// outermost. It returns nil when interceptors is empty.
+func Chain(interceptors ...Interceptor) Interceptor {
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	}
+	first, rest := interceptors[0], Chain(interceptors[1:]...)
+	return func(ctx context.Context, call *Call, next Invoker) error {
+		return first(ctx, call, func(ctx context.Context, call *Call) error {
+			return rest(ctx, call, next)
+		})
+	}
+}
+
+// Invoke sends the request through interceptor, which may be nil.
+func Invoke(ctx context.Context, interceptor Interceptor, call *Call, invoker Invoker) error {
+	if interceptor == nil {
+		return invoker(ctx, call)
+	}
+	return interceptor(ctx, call, invoker)
+}
+
+// Route strips the parameters of path: the first element is kept, or the first two
+// for custom ABCI queries.
+func Route(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	prefix := ""
+	if strings.HasPrefix(path, "/") {
+		prefix, path = "/", path[1:]
+	}
+	parts := strings.SplitN(path, "/", 3)
+	if parts[0] == "custom" && len(parts) > 1 {
+		return prefix + parts[0] + "/" + parts[1]
+	}
+	return prefix + parts[0]
+}
+
+// ErrorCode returns a short code for err, suitable as a metric label: empty for nil,
+// the ABCI code or HTTP status of the node, canceled, timeout or error.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	var abciErr *tx.ABCIError
+	if errors.As(err, &abciErr) {
+		return strconv.FormatUint(uint64(abciErr.Code), 10)
+	}
+	var httpErr *tx.HTTPError
+	if errors.As(err, &httpErr) {
+		return strconv.Itoa(httpErr.StatusCode)
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return "error"
+}
+
+// Logging logs every call, successful ones at debug level and failed ones at error level.
+func Logging(logger log.Logger) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) error {
+		start := time.Now()
+		err := next(ctx, call)
+		keyvals := []interface{}{
+			"client", call.Client,
+			"method", call.Method,
+			"path", call.Path,
+			"endpoint", call.Endpoint,
+			"latency", time.Since(start),
+		}
+		if call.TxHash != "" {
+			keyvals = append(keyvals, "tx_hash", call.TxHash)
+		}
+		if err != nil {
+			logger.Error("call failed", append(keyvals, "code", ErrorCode(err), "err", err)...)
+		} else {
+			logger.Debug("call", keyvals...)
+		}
+		return err
+	}
+}
@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func TestChain(t *testing.T) {
+	assert.Nil(t, Chain())
+
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, next Invoker) error {
+			order = append(order, name)
+			return next(ctx, call)
+		}
+	}
+	errSend := errors.New("send")
+	err := Invoke(context.Background(), Chain(record("a"), record("b"), record("c")), &Call{}, func(ctx context.Context, call *Call) error {
+		order = append(order, "send")
+		return errSend
+	})
+	assert.Equal(t, errSend, err)
+	assert.Equal(t, []string{"a", "b", "c", "send"}, order)
+}
+
+func TestRoute(t *testing.T) {
+	assert.Equal(t, "/account", Route("/account/bnb1xyz"))
+	assert.Equal(t, "/broadcast", Route("/broadcast?sync=true"))
+	assert.Equal(t, "custom/gov", Route("custom/gov/proposals"))
+	assert.Equal(t, "/store", Route("/store/acc/key"))
+	assert.Equal(t, "", Route(""))
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "", ErrorCode(nil))
+	assert.Equal(t, "65541", ErrorCode(fmt.Errorf("broadcast: %w", tx.NewABCIError(65541, "insufficient"))))
+	assert.Equal(t, "429", ErrorCode(tx.NewHTTPError(429, nil)))
+	assert.Equal(t, "timeout", ErrorCode(context.DeadlineExceeded))
+	assert.Equal(t, "canceled", ErrorCode(context.Canceled))
+	assert.Equal(t, "error", ErrorCode(errors.New("boom")))
+}
@@ -0,0 +1,61 @@
+// Package metrics exports the calls of the clients as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+)
+
+var labels = []string{"client", "method", "route", "endpoint", "code"}
+
+// Metrics counts the calls of the clients and observes their latency, labelled by
+// client, method, route, endpoint and error code. It is a prometheus.Collector.
+type Metrics struct {
+	calls   *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+// NewMetrics returns the metrics of namespace, e.g. bnb_sdk_calls_total for bnb.
+func NewMetrics(namespace string) *Metrics {
+	return &Metrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "calls_total",
+			Help:      "Number of calls to the nodes, the code is empty for successful calls.",
+		}, labels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "sdk",
+			Name:      "call_duration_seconds",
+			Help:      "Latency of the calls to the nodes.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.calls.Describe(ch)
+	m.latency.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.calls.Collect(ch)
+	m.latency.Collect(ch)
+}
+
+// Interceptor records every call to m.
+func (m *Metrics) Interceptor() interceptor.Interceptor {
+	return func(ctx context.Context, call *interceptor.Call, next interceptor.Invoker) error {
+		start := time.Now()
+		err := next(ctx, call)
+		values := []string{call.Client, call.Method, call.Route, call.Endpoint, interceptor.ErrorCode(err)}
+		m.calls.WithLabelValues(values...).Inc()
+		m.latency.WithLabelValues(values...).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
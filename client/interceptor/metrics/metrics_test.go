@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics("bnb")
+	assert.NoError(t, prometheus.NewRegistry().Register(m))
+
+	call := &interceptor.Call{Client: interceptor.ClientRPC, Method: "abci_query", Route: "/account", Endpoint: "tcp://node:27147"}
+	ok := func(ctx context.Context, call *interceptor.Call) error { return nil }
+	fail := func(ctx context.Context, call *interceptor.Call) error { return errors.New("boom") }
+	in := m.Interceptor()
+	assert.NoError(t, in(context.Background(), call, ok))
+	assert.NoError(t, in(context.Background(), call, ok))
+	assert.Error(t, in(context.Background(), call, fail))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.calls.WithLabelValues("rpc", "abci_query", "/account", "tcp://node:27147", "")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.calls.WithLabelValues("rpc", "abci_query", "/account", "tcp://node:27147", "error")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.latency))
+}
@@ -0,0 +1,40 @@
+// Package tracing traces the calls of the clients with OpenTelemetry.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+)
+
+// Interceptor starts a client span for every call, named after its method and route,
+// e.g. "abci_query /account" or "POST /broadcast".
+func Interceptor(tracer trace.Tracer) interceptor.Interceptor {
+	return func(ctx context.Context, call *interceptor.Call, next interceptor.Invoker) error {
+		name := call.Method
+		if call.Route != "" {
+			name += " " + call.Route
+		}
+		ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			attribute.String("bnb.client", call.Client),
+			attribute.String("bnb.method", call.Method),
+			attribute.String("bnb.path", call.Path),
+			attribute.String("bnb.endpoint", call.Endpoint),
+		))
+		defer span.End()
+		err := next(ctx, call)
+		if call.TxHash != "" {
+			span.SetAttributes(attribute.String("bnb.tx_hash", call.TxHash))
+		}
+		if err != nil {
+			span.SetAttributes(attribute.String("bnb.error_code", interceptor.ErrorCode(err)))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
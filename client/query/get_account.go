@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -9,12 +10,16 @@ import (
 
 // GetAccount returns list of trading pairs
 func (c *client) GetAccount(address string) (*types.BalanceAccount, error) {
+	return c.GetAccountCtx(context.Background(), address)
+}
+
+func (c *client) GetAccountCtx(ctx context.Context, address string) (*types.BalanceAccount, error) {
 	if address == "" {
 		return nil, types.AddressMissingError
 	}
 
 	qp := map[string]string{}
-	resp, code, err := c.baseClient.Get("/account/"+address, qp)
+	resp, code, err := c.baseClient.GetCtx(ctx, "/account/"+address, qp)
 	if err != nil {
 		if code == http.StatusNotFound {
 			return &types.BalanceAccount{}, nil
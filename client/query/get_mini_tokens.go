@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/bnb-chain/go-sdk/common"
@@ -9,11 +10,15 @@ import (
 
 // GetMiniTokens returns list of mini tokens
 func (c *client) GetMiniTokens(query *types.TokensQuery) ([]types.MiniToken, error) {
+	return c.GetMiniTokensCtx(context.Background(), query)
+}
+
+func (c *client) GetMiniTokensCtx(ctx context.Context, query *types.TokensQuery) ([]types.MiniToken, error) {
 	qp, err := common.QueryParamToMap(*query)
 	if err != nil {
 		return nil, err
 	}
-	resp, _, err := c.baseClient.Get("/mini/tokens", qp)
+	resp, _, err := c.baseClient.GetCtx(ctx, "/mini/tokens", qp)
 	if err != nil {
 		return nil, err
 	}
@@ -1,14 +1,19 @@
 package query
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/bnb-chain/go-sdk/common/types"
 )
 
 func (c *client) GetNodeInfo() (*types.ResultStatus, error) {
+	return c.GetNodeInfoCtx(context.Background())
+}
+
+func (c *client) GetNodeInfoCtx(ctx context.Context) (*types.ResultStatus, error) {
 	qp := map[string]string{}
-	resp, _, err := c.baseClient.Get("/node-info", qp)
+	resp, _, err := c.baseClient.GetCtx(ctx, "/node-info", qp)
 	if err != nil {
 		return nil, err
 	}
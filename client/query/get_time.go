@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/bnb-chain/go-sdk/common/types"
@@ -8,8 +9,12 @@ import (
 
 // GetTime returns market depth records
 func (c *client) GetTime() (*types.Time, error) {
+	return c.GetTimeCtx(context.Background())
+}
+
+func (c *client) GetTimeCtx(ctx context.Context) (*types.Time, error) {
 	qp := map[string]string{}
-	resp, _, err := c.baseClient.Get("/time", qp)
+	resp, _, err := c.baseClient.GetCtx(ctx, "/time", qp)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/bnb-chain/go-sdk/common"
@@ -9,11 +10,15 @@ import (
 
 // GetTokens returns list of tokens
 func (c *client) GetTokens(query *types.TokensQuery) ([]types.Token, error) {
+	return c.GetTokensCtx(context.Background(), query)
+}
+
+func (c *client) GetTokensCtx(ctx context.Context, query *types.TokensQuery) ([]types.Token, error) {
 	qp, err := common.QueryParamToMap(*query)
 	if err != nil {
 		return nil, err
 	}
-	resp, _, err := c.baseClient.Get("/tokens", qp)
+	resp, _, err := c.baseClient.GetCtx(ctx, "/tokens", qp)
 	if err != nil {
 		return nil, err
 	}
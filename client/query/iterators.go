@@ -0,0 +1,42 @@
+package query
+
+import (
+	"context"
+
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/common/types"
+)
+
+// TokenIterator walks the tokens of GetTokens, limit by limit:
+//
+//	it := query.IterateTokens(client, 100)
+//	for it.Next() {
+//		fmt.Println(it.Token().Symbol)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TokenIterator struct {
+	*common.Pager
+	page []types.Token
+}
+
+// IterateTokens returns an iterator over all the tokens of c, fetching limit tokens at
+// a time, common.DefaultPageLimit if zero.
+func IterateTokens(c QueryClient, limit uint32) *TokenIterator {
+	it := &TokenIterator{}
+	it.Pager = common.NewPager(int(limit), func(ctx context.Context, offset, limit int) (int, bool, error) {
+		query := types.NewTokensQuery().WithOffset(uint32(offset)).WithLimit(uint32(limit))
+		if err := query.Check(); err != nil {
+			return 0, true, err
+		}
+		tokens, err := c.GetTokensCtx(ctx, query)
+		it.page = tokens
+		return len(tokens), len(tokens) < limit, err
+	})
+	return it
+}
+
+func (it *TokenIterator) Token() types.Token {
+	return it.page[it.Index()]
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"context"
+
 	"github.com/bnb-chain/go-sdk/client/basic"
 	"github.com/bnb-chain/go-sdk/common/types"
 )
@@ -11,6 +13,12 @@ type QueryClient interface {
 	GetTokens(query *types.TokensQuery) ([]types.Token, error)
 	GetNodeInfo() (*types.ResultStatus, error)
 	GetMiniTokens(query *types.TokensQuery) ([]types.MiniToken, error)
+
+	GetAccountCtx(ctx context.Context, address string) (*types.BalanceAccount, error)
+	GetTimeCtx(ctx context.Context) (*types.Time, error)
+	GetTokensCtx(ctx context.Context, query *types.TokensQuery) ([]types.Token, error)
+	GetNodeInfoCtx(ctx context.Context) (*types.ResultStatus, error)
+	GetMiniTokensCtx(ctx context.Context, query *types.TokensQuery) ([]types.MiniToken, error)
 }
 
 type client struct {
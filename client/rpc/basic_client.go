@@ -1,16 +1,17 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"time"
 
-	"github.com/pkg/errors"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
 	"github.com/tendermint/tendermint/types"
 
+	"github.com/bnb-chain/go-sdk/common"
 	ntypes "github.com/bnb-chain/go-sdk/common/types"
 	"github.com/bnb-chain/go-sdk/keys"
 	"github.com/bnb-chain/go-sdk/types/tx"
@@ -29,6 +30,13 @@ type ABCIClient interface {
 	BroadcastTxCommit(tx types.Tx) (*ResultBroadcastTxCommit, error)
 	BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+
+	ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error)
+	ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error)
+	ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error)
+	BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*ResultBroadcastTxCommit, error)
+	BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+	BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 }
 
 type SignClient interface {
@@ -38,6 +46,13 @@ type SignClient interface {
 	Validators(height *int64) (*ctypes.ResultValidators, error)
 	Tx(hash []byte, prove bool) (*ResultTx, error)
 	TxSearch(query string, prove bool, page, perPage int) (*ResultTxSearch, error)
+
+	BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResultsCtx(ctx context.Context, height *int64) (*ResultBlockResults, error)
+	CommitCtx(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
+	ValidatorsCtx(ctx context.Context, height *int64) (*ctypes.ResultValidators, error)
+	TxCtx(ctx context.Context, hash []byte, prove bool) (*ResultTx, error)
+	TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*ResultTxSearch, error)
 }
 
 type Client interface {
@@ -50,6 +65,10 @@ type Client interface {
 	DexClient
 	OpsClient
 	StakingClient
+
+	StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error)
+	BlockchainInfoCtx(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	GenesisCtx(ctx context.Context) (*ctypes.ResultGenesis, error)
 }
 
 type EventsClient interface {
@@ -58,20 +77,23 @@ type EventsClient interface {
 	UnsubscribeAll() error
 }
 
-func NewRPCClient(nodeURI string, network ntypes.ChainNetwork) *HTTP {
+func NewRPCClient(nodeURI string, network ntypes.ChainNetwork, options ...common.TransportOption) *HTTP {
 	ntypes.SetNetwork(network)
-	return NewHTTP(nodeURI, "/websocket")
+	return NewHTTP(nodeURI, "/websocket", options...)
 }
 
 type HTTP struct {
 	*WSEvents
 
-	key keys.KeyManager
+	key        keys.KeyManager
+	seqManager tx.SequenceManager
+	verifier   *Verifier
 }
 
 // NewHTTP takes a remote endpoint in the form tcp://<host>:<port>
-// and the websocket path (which always seems to be "/websocket")
-func NewHTTP(remote, wsEndpoint string) *HTTP {
+// and the websocket path (which always seems to be "/websocket"). The TLS config,
+// headers, scheme and proxy of options apply to the websocket connection.
+func NewHTTP(remote, wsEndpoint string, options ...common.TransportOption) *HTTP {
 	rc := rpcclient.NewJSONRPCClient(remote)
 	cdc := rc.Codec()
 	ctypes.RegisterAmino(cdc)
@@ -79,7 +101,7 @@ func NewHTTP(remote, wsEndpoint string) *HTTP {
 	tx.RegisterCodec(cdc)
 
 	rc.SetCodec(cdc)
-	wsEvent := newWSEvents(cdc, remote, wsEndpoint)
+	wsEvent := newWSEvents(cdc, remote, wsEndpoint, common.NewTransportConfig(options...))
 	client := &HTTP{
 		WSEvents: wsEvent,
 	}
@@ -88,130 +110,218 @@ func NewHTTP(remote, wsEndpoint string) *HTTP {
 }
 
 func (c *HTTP) Status() (*ctypes.ResultStatus, error) {
-	return c.WSEvents.Status()
+	return c.StatusCtx(context.Background())
+}
+
+func (c *HTTP) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
+	return c.WSEvents.StatusCtx(ctx)
 }
 
 func (c *HTTP) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
-	return c.WSEvents.ABCIInfo()
+	return c.ABCIInfoCtx(context.Background())
+}
+
+func (c *HTTP) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	return c.WSEvents.ABCIInfoCtx(ctx)
 }
 
 func (c *HTTP) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
-	return c.ABCIQueryWithOptions(path, data, client.DefaultABCIQueryOptions)
+	return c.ABCIQueryCtx(context.Background(), path, data)
+}
+
+func (c *HTTP) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptionsCtx(ctx, path, data, client.DefaultABCIQueryOptions)
 }
 
 func (c *HTTP) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptionsCtx(context.Background(), path, data, opts)
+}
+
+func (c *HTTP) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
 	if err := ValidateABCIPath(path); err != nil {
 		return nil, err
 	}
 	if err := ValidateABCIData(data); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.ABCIQueryWithOptions(path, data, opts)
+	return c.WSEvents.ABCIQueryWithOptionsCtx(ctx, path, data, opts)
 }
 
 func (c *HTTP) BroadcastTxCommit(tx types.Tx) (*ResultBroadcastTxCommit, error) {
+	return c.BroadcastTxCommitCtx(context.Background(), tx)
+}
+
+func (c *HTTP) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*ResultBroadcastTxCommit, error) {
 	if err := ValidateTx(tx); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BroadcastTxCommit(tx)
+	return c.WSEvents.BroadcastTxCommitCtx(ctx, tx)
 }
 
 func (c *HTTP) BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.BroadcastTxAsyncCtx(context.Background(), tx)
+}
+
+func (c *HTTP) BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	if err := ValidateTx(tx); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BroadcastTx("broadcast_tx_async", tx)
+	return c.WSEvents.BroadcastTxCtx(ctx, "broadcast_tx_async", tx)
 }
 
 func (c *HTTP) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.BroadcastTxSyncCtx(context.Background(), tx)
+}
+
+func (c *HTTP) BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	if err := ValidateTx(tx); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BroadcastTx("broadcast_tx_sync", tx)
+	return c.WSEvents.BroadcastTxCtx(ctx, "broadcast_tx_sync", tx)
 }
 
 func (c *HTTP) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.UnconfirmedTxsCtx(context.Background(), limit)
+}
+
+func (c *HTTP) UnconfirmedTxsCtx(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error) {
 	if err := ValidateUnConfirmedTxsLimit(limit); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.UnconfirmedTxs(limit)
+	return c.WSEvents.UnconfirmedTxsCtx(ctx, limit)
 }
 
 func (c *HTTP) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
-	return c.WSEvents.NumUnconfirmedTxs()
+	return c.NumUnconfirmedTxsCtx(context.Background())
+}
+
+func (c *HTTP) NumUnconfirmedTxsCtx(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	return c.WSEvents.NumUnconfirmedTxsCtx(ctx)
 }
 
 func (c *HTTP) NetInfo() (*ctypes.ResultNetInfo, error) {
-	return c.WSEvents.NetInfo()
+	return c.NetInfoCtx(context.Background())
+}
+
+func (c *HTTP) NetInfoCtx(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	return c.WSEvents.NetInfoCtx(ctx)
 }
 
 func (c *HTTP) DumpConsensusState() (*ctypes.ResultDumpConsensusState, error) {
-	return c.WSEvents.DumpConsensusState()
+	return c.DumpConsensusStateCtx(context.Background())
+}
+
+func (c *HTTP) DumpConsensusStateCtx(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	return c.WSEvents.DumpConsensusStateCtx(ctx)
 }
 
 func (c *HTTP) ConsensusState() (*ctypes.ResultConsensusState, error) {
-	return c.WSEvents.ConsensusState()
+	return c.ConsensusStateCtx(context.Background())
+}
+
+func (c *HTTP) ConsensusStateCtx(ctx context.Context) (*ctypes.ResultConsensusState, error) {
+	return c.WSEvents.ConsensusStateCtx(ctx)
 }
 
 func (c *HTTP) Health() (*ctypes.ResultHealth, error) {
-	return c.WSEvents.Health()
+	return c.HealthCtx(context.Background())
+}
+
+func (c *HTTP) HealthCtx(ctx context.Context) (*ctypes.ResultHealth, error) {
+	return c.WSEvents.HealthCtx(ctx)
 }
 
 func (c *HTTP) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	return c.BlockchainInfoCtx(context.Background(), minHeight, maxHeight)
+}
+
+func (c *HTTP) BlockchainInfoCtx(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	if err := ValidateHeightRange(minHeight, maxHeight); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BlockchainInfo(minHeight, maxHeight)
+	return c.WSEvents.BlockchainInfoCtx(ctx, minHeight, maxHeight)
 }
 
 func (c *HTTP) Genesis() (*ctypes.ResultGenesis, error) {
-	return c.WSEvents.Genesis()
+	return c.GenesisCtx(context.Background())
+}
+
+func (c *HTTP) GenesisCtx(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	return c.WSEvents.GenesisCtx(ctx)
 }
 
 func (c *HTTP) Block(height *int64) (*ctypes.ResultBlock, error) {
+	return c.BlockCtx(context.Background(), height)
+}
+
+func (c *HTTP) BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	if err := ValidateHeight(height); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.Block(height)
+	return c.WSEvents.BlockCtx(ctx, height)
 }
 
 func (c *HTTP) BlockResults(height *int64) (*ResultBlockResults, error) {
+	return c.BlockResultsCtx(context.Background(), height)
+}
+
+func (c *HTTP) BlockResultsCtx(ctx context.Context, height *int64) (*ResultBlockResults, error) {
 	if err := ValidateHeight(height); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.BlockResults(height)
+	return c.WSEvents.BlockResultsCtx(ctx, height)
 }
 
 func (c *HTTP) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	return c.CommitCtx(context.Background(), height)
+}
+
+func (c *HTTP) CommitCtx(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	if err := ValidateHeight(height); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.Commit(height)
+	return c.WSEvents.CommitCtx(ctx, height)
 }
 
 func (c *HTTP) Tx(hash []byte, prove bool) (*ResultTx, error) {
+	return c.TxCtx(context.Background(), hash, prove)
+}
+
+func (c *HTTP) TxCtx(ctx context.Context, hash []byte, prove bool) (*ResultTx, error) {
 	if err := ValidateHash(hash); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.Tx(hash, prove)
+	return c.WSEvents.TxCtx(ctx, hash, prove)
 }
 
 func (c *HTTP) TxSearch(query string, prove bool, page, perPage int) (*ResultTxSearch, error) {
+	return c.TxSearchCtx(context.Background(), query, prove, page, perPage)
+}
+
+func (c *HTTP) TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*ResultTxSearch, error) {
 	if err := ValidateABCIQueryStr(query); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.TxSearch(query, prove, page, perPage)
+	return c.WSEvents.TxSearchCtx(ctx, query, prove, page, perPage)
 }
 
 func (c *HTTP) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	return c.ValidatorsCtx(context.Background(), height)
+}
+
+func (c *HTTP) ValidatorsCtx(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
 	if err := ValidateHeight(height); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.Validators(height)
+	return c.WSEvents.ValidatorsCtx(ctx, height)
 }
 
 func (c *HTTP) QueryWithData(path string, data cmn.HexBytes) ([]byte, error) {
-	result, err := c.ABCIQuery(path, data)
+	return c.QueryWithDataCtx(context.Background(), path, data)
+}
+
+func (c *HTTP) QueryWithDataCtx(ctx context.Context, path string, data cmn.HexBytes) ([]byte, error) {
+	result, err := c.ABCIQueryCtx(ctx, path, data)
 
 	if err != nil {
 		return nil, err
@@ -219,35 +329,49 @@ func (c *HTTP) QueryWithData(path string, data cmn.HexBytes) ([]byte, error) {
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
+		return nil, tx.NewABCIError(resp.Code, resp.Log)
 	}
 
 	return resp.Value, nil
 }
 
 func (c *HTTP) QueryStore(key cmn.HexBytes, storeName string) ([]byte, error) {
+	return c.QueryStoreCtx(context.Background(), key, storeName)
+}
+
+func (c *HTTP) QueryStoreCtx(ctx context.Context, key cmn.HexBytes, storeName string) ([]byte, error) {
+	if c.verifier != nil {
+		return c.queryStoreVerified(ctx, key, storeName)
+	}
 	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
-	result, err := c.ABCIQuery(path, key)
+	result, err := c.ABCIQueryCtx(ctx, path, key)
 	if err != nil {
 		return nil, err
 	}
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
+		return nil, tx.NewABCIError(resp.Code, resp.Log)
 	}
 	return resp.Value, nil
 }
 
 func (c *HTTP) QueryStoreSubspace(key cmn.HexBytes, storeName string) (res []cmn.KVPair, err error) {
+	return c.QueryStoreSubspaceCtx(context.Background(), key, storeName)
+}
+
+func (c *HTTP) QueryStoreSubspaceCtx(ctx context.Context, key cmn.HexBytes, storeName string) (res []cmn.KVPair, err error) {
+	if c.verifier != nil {
+		return nil, SubspaceNotVerifiableError
+	}
 	path := fmt.Sprintf("/store/%s/subspace", storeName)
-	result, err := c.ABCIQuery(path, key)
+	result, err := c.ABCIQueryCtx(ctx, path, key)
 	if err != nil {
 		return res, err
 	}
 
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.Errorf(resp.Log)
+		return nil, tx.NewABCIError(resp.Code, resp.Log)
 	}
 
 	if len(resp.Value) == 0 {
@@ -266,3 +390,9 @@ func (c *HTTP) QueryStoreSubspace(key cmn.HexBytes, storeName string) (res []cmn
 func (c *HTTP) SetKeyManager(k keys.KeyManager) {
 	c.key = k
 }
+
+// SetSequenceManager makes Broadcast take account numbers and sequences from m
+// instead of querying the account before every transaction.
+func (c *HTTP) SetSequenceManager(m tx.SequenceManager) {
+	c.seqManager = m
+}
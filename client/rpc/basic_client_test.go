@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/go-amino"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+)
+
+func TestCallCtxCancel(t *testing.T) {
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	// the node only answers once reply is set
+	var reply int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var req rpctypes.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			if atomic.LoadInt32(&reply) == 1 {
+				bz, _ := cdc.MarshalJSON(ctypes.ResultStatus{})
+				conn.WriteJSON(rpctypes.RPCResponse{JSONRPC: "2.0", ID: req.ID, Result: bz})
+			}
+		}
+	}))
+	defer server.Close()
+	c := NewHTTP("tcp://"+strings.TrimPrefix(server.URL, "http://"), "/websocket")
+	defer c.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := c.StatusCtx(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < time.Second)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.GetAccountCtx(ctx, types.AccAddress("alice"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	_, err = c.StatusCtx(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// the connection is still usable after the callers gave up
+	atomic.StoreInt32(&reply, 1)
+	_, err = c.StatusCtx(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/common"
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+const (
+	defaultDepositPollInterval = 10 * time.Second
+	depositPerPage             = 100
+	// depositSearchBlocks bounds the blocks searched at once when catching up
+	depositSearchBlocks = 1000
+)
+
+// DepositClient is the part of the rpc client used by a DepositWatcher. *HTTP implements it.
+type DepositClient interface {
+	StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error)
+	TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*ResultTxSearch, error)
+	SubscribeNewBlocks(ctx context.Context, opts SubscribeOptions) (<-chan types.EventDataNewBlock, error)
+}
+
+// DepositOptions controls where a DepositWatcher starts and when deposits are confirmed.
+type DepositOptions struct {
+	// FromHeight is the first block searched for deposits, e.g. the height of the
+	// oldest deposit not confirmed yet when restarting. Only the next blocks are by
+	// default.
+	FromHeight int64
+	// Confirmations is the number of blocks to wait for on top of the one including a
+	// deposit before it is confirmed.
+	Confirmations int64
+	// Pending also hands the deposits as soon as they are seen, with Confirmed false,
+	// before handing them again once confirmed. Only the confirmed deposits are by default.
+	Pending bool
+	// PollInterval is how often the latest height is queried, in case NewBlock events
+	// were missed. 10s by default.
+	PollInterval time.Duration
+	// Retry controls the retries of the failed queries.
+	Retry common.RetryOptions
+}
+
+// Deposit is an output of a successful SendMsg to a watched address.
+type Deposit struct {
+	// ID identifies the deposit across restarts, as "<tx hash>:<msg index>:<output index>".
+	ID          string
+	TxHash      cmn.HexBytes
+	Height      int64
+	MsgIndex    int
+	OutputIndex int
+	Address     ntypes.AccAddress
+	Coins       ntypes.Coins
+	// Senders are the addresses of the inputs of the msg.
+	Senders []ntypes.AccAddress
+	Memo    string
+	// MemoMatched is false when the address is watched with memos and the tx has none
+	// of them, so that the deposit can't be credited and should be refunded.
+	MemoMatched   bool
+	Confirmations int64
+	// Confirmed is false for the deposits seen before they have the confirmations
+	// required, only handed with DepositOptions.Pending. They are handed again once
+	// confirmed, with the same ID.
+	Confirmed bool
+}
+
+// DepositHandler handles a deposit. A deposit may be handled again after a restart,
+// so handlers should use its ID and Confirmed together to handle it only once. With
+// the default options, all the deposits are confirmed and the ID alone does.
+type DepositHandler func(ctx context.Context, deposit Deposit) error
+
+// DepositWatcher finds the deposits to a set of addresses in the new blocks, and tracks
+// their confirmations. It is safe for concurrent use.
+type DepositWatcher struct {
+	client DepositClient
+	opts   DepositOptions
+
+	mtx     sync.RWMutex
+	watched map[string]map[string]struct{}
+}
+
+func NewDepositWatcher(client DepositClient, opts DepositOptions) *DepositWatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultDepositPollInterval
+	}
+	if opts.Confirmations < 0 {
+		opts.Confirmations = 0
+	}
+	return &DepositWatcher{client: client, opts: opts, watched: make(map[string]map[string]struct{})}
+}
+
+// Watch looks for the deposits to addr. With memos, the deposits must carry one of
+// them, otherwise they are reported with MemoMatched false. Watching addr again
+// replaces its memos.
+func (d *DepositWatcher) Watch(addr ntypes.AccAddress, memos ...string) {
+	var set map[string]struct{}
+	if len(memos) > 0 {
+		set = make(map[string]struct{}, len(memos))
+		for _, memo := range memos {
+			set[memo] = struct{}{}
+		}
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.watched[string(addr)] = set
+}
+
+func (d *DepositWatcher) Unwatch(addr ntypes.AccAddress) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	delete(d.watched, string(addr))
+}
+
+// Run hands the deposits to handle until ctx is done, handle fails or the node can't
+// be queried. It never returns nil.
+func (d *DepositWatcher) Run(ctx context.Context, handle DepositHandler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// the blocks only tell when to search, missing some is fine
+	blocks, err := d.client.SubscribeNewBlocks(ctx, SubscribeOptions{Capacity: 1, Overflow: OverflowDropOldest})
+	if err != nil {
+		return err
+	}
+	latest, err := d.latestHeight(ctx)
+	if err != nil {
+		return err
+	}
+	next := d.opts.FromHeight
+	if next <= 0 {
+		next = latest + 1
+	}
+
+	var pending []pendingDeposit
+	for {
+		if next <= latest {
+			to := next + depositSearchBlocks - 1
+			if to > latest {
+				to = latest
+			}
+			deposits, err := d.search(ctx, next, to)
+			if err != nil {
+				return err
+			}
+			for _, deposit := range deposits {
+				pending = append(pending, pendingDeposit{Deposit: deposit})
+			}
+			next = to + 1
+		}
+		if pending, err = d.confirm(ctx, pending, latest, handle); err != nil {
+			return err
+		}
+		if next <= latest {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case block, ok := <-blocks:
+			if !ok {
+				return ctx.Err()
+			}
+			if block.Block != nil && block.Block.Height > latest {
+				latest = block.Block.Height
+			}
+		case <-time.After(d.opts.PollInterval):
+			height, err := d.latestHeight(ctx)
+			if err != nil {
+				return err
+			}
+			if height > latest {
+				latest = height
+			}
+		}
+	}
+}
+
+type pendingDeposit struct {
+	Deposit
+	handled bool
+}
+
+// confirm hands the deposits in pending with enough confirmations at height latest to
+// handle, and returns the others. With the Pending option, the new deposits are handled
+// as not confirmed first.
+func (d *DepositWatcher) confirm(ctx context.Context, pending []pendingDeposit, latest int64, handle DepositHandler) ([]pendingDeposit, error) {
+	var left []pendingDeposit
+	for _, p := range pending {
+		p.Confirmations = latest - p.Height
+		p.Confirmed = p.Confirmations >= d.opts.Confirmations
+		if p.Confirmed || (d.opts.Pending && !p.handled) {
+			if err := handle(ctx, p.Deposit); err != nil {
+				return nil, err
+			}
+			p.handled = true
+		}
+		if !p.Confirmed {
+			left = append(left, p)
+		}
+	}
+	return left, nil
+}
+
+func (d *DepositWatcher) latestHeight(ctx context.Context) (int64, error) {
+	var status *ctypes.ResultStatus
+	err := common.Retry(ctx, d.opts.Retry, nil, func() (err error) {
+		status, err = d.client.StatusCtx(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// search returns the deposits in the blocks from height from to height to. The tx
+// indexer of the chain doesn't index the addresses, so all the txs are fetched.
+func (d *DepositWatcher) search(ctx context.Context, from, to int64) ([]Deposit, error) {
+	query := fmt.Sprintf("tx.height>=%d AND tx.height<=%d", from, to)
+	var deposits []Deposit
+	for page, seen := 1, 0; ; page++ {
+		var res *ResultTxSearch
+		err := common.Retry(ctx, d.opts.Retry, nil, func() (err error) {
+			res, err = d.client.TxSearchCtx(ctx, query, false, page, depositPerPage)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range res.Txs {
+			deposits = append(deposits, d.deposits(t)...)
+		}
+		seen += len(res.Txs)
+		if len(res.Txs) < depositPerPage || seen >= res.TotalCount {
+			return deposits, nil
+		}
+	}
+}
+
+// deposits returns the deposits of t to the watched addresses.
+func (d *DepositWatcher) deposits(t *ResultTx) []Deposit {
+	if t.TxResult.Code != 0 {
+		return nil
+	}
+	parsed, err := ParseTx(tx.Cdc, t.Tx)
+	if err != nil {
+		// can't be a transfer
+		return nil
+	}
+	var memo string
+	if stdTx, ok := parsed.(tx.StdTx); ok {
+		memo = stdTx.Memo
+	}
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	var deposits []Deposit
+	for i, m := range parsed.GetMsgs() {
+		send, ok := m.(msg.SendMsg)
+		if !ok {
+			continue
+		}
+		for j, out := range send.Outputs {
+			memos, watched := d.watched[string(out.Address)]
+			if !watched {
+				continue
+			}
+			_, matched := memos[memo]
+			deposit := Deposit{
+				ID:          fmt.Sprintf("%X:%d:%d", t.Hash, i, j),
+				TxHash:      t.Hash,
+				Height:      t.Height,
+				MsgIndex:    i,
+				OutputIndex: j,
+				Address:     out.Address,
+				Coins:       out.Coins,
+				Memo:        memo,
+				MemoMatched: memos == nil || matched,
+			}
+			for _, in := range send.Inputs {
+				deposit.Senders = append(deposit.Senders, in.Address)
+			}
+			deposits = append(deposits, deposit)
+		}
+	}
+	return deposits
+}
@@ -0,0 +1,207 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+type fakeDepositClient struct {
+	mtx    sync.Mutex
+	latest int64
+	txs    map[int64][]*ResultTx
+	blocks chan types.EventDataNewBlock
+}
+
+func (c *fakeDepositClient) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *fakeDepositClient) TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*ResultTxSearch, error) {
+	var from, to int64
+	if _, err := fmt.Sscanf(query, "tx.height>=%d AND tx.height<=%d", &from, &to); err != nil {
+		return nil, err
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	res := &ResultTxSearch{}
+	for height := from; height <= to; height++ {
+		res.Txs = append(res.Txs, c.txs[height]...)
+	}
+	res.TotalCount = len(res.Txs)
+	return res, nil
+}
+
+func (c *fakeDepositClient) SubscribeNewBlocks(ctx context.Context, opts SubscribeOptions) (<-chan types.EventDataNewBlock, error) {
+	return c.blocks, nil
+}
+
+// commit adds a block with txs.
+func (c *fakeDepositClient) commit(txs ...*ResultTx) {
+	c.mtx.Lock()
+	c.latest++
+	height := c.latest
+	for _, t := range txs {
+		t.Height = height
+		c.txs[height] = append(c.txs[height], t)
+	}
+	c.mtx.Unlock()
+	c.blocks <- types.EventDataNewBlock{Block: &types.Block{Header: types.Header{Height: height}}}
+}
+
+func TestDepositWatcher(t *testing.T) {
+	exchange, user, other := ntypes.AccAddress("exchange"), ntypes.AccAddress("user"), ntypes.AccAddress("other")
+	coins := ntypes.Coins{{Denom: "BNB", Amount: 1}}
+	send := func(hash, memo string, code uint32, outputs ...ntypes.AccAddress) *ResultTx {
+		m := msg.SendMsg{Inputs: []msg.Input{msg.NewInput(user, coins)}}
+		for _, out := range outputs {
+			m.Outputs = append(m.Outputs, msg.NewOutput(out, coins))
+		}
+		bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Memo: memo, Msgs: []msg.Msg{m}})
+		assert.NoError(t, err)
+		return &ResultTx{Hash: []byte(hash), Tx: bz, TxResult: ResponseDeliverTx{Code: code}}
+	}
+
+	c := &fakeDepositClient{latest: 10, txs: make(map[int64][]*ResultTx), blocks: make(chan types.EventDataNewBlock)}
+	// a deposit made before starting
+	c.txs[9] = []*ResultTx{send("a", "42", 0, exchange)}
+	c.txs[9][0].Height = 9
+
+	w := NewDepositWatcher(c, DepositOptions{FromHeight: 9, Confirmations: 2, Pending: true, PollInterval: time.Hour})
+	w.Watch(exchange, "42", "43")
+	deposits := make(chan Deposit, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(ctx context.Context, deposit Deposit) error {
+			deposits <- deposit
+			return nil
+		})
+	}()
+
+	next := func() Deposit {
+		select {
+		case d := <-deposits:
+			return d
+		case <-time.After(time.Second):
+			t.Fatal("no deposit")
+			return Deposit{}
+		}
+	}
+	d := next()
+	assert.Equal(t, "61:0:0", d.ID)
+	assert.EqualValues(t, 9, d.Height)
+	assert.EqualValues(t, 1, d.Confirmations)
+	assert.False(t, d.Confirmed)
+	assert.True(t, d.MemoMatched)
+	assert.Equal(t, []ntypes.AccAddress{user}, d.Senders)
+
+	// a multi-send with a wrong memo, a failed tx and a transfer to another address
+	c.commit(send("b", "x", 0, other, exchange, exchange), send("c", "42", 1, exchange), send("d", "42", 0, other))
+	d = next()
+	assert.Equal(t, "61:0:0", d.ID)
+	assert.True(t, d.Confirmed)
+	for i := 1; i <= 2; i++ {
+		d = next()
+		assert.Equal(t, fmt.Sprintf("62:0:%d", i), d.ID)
+		assert.False(t, d.MemoMatched)
+		assert.False(t, d.Confirmed)
+		assert.EqualValues(t, 0, d.Confirmations)
+	}
+
+	c.commit()
+	c.commit()
+	for i := 1; i <= 2; i++ {
+		d = next()
+		assert.Equal(t, fmt.Sprintf("62:0:%d", i), d.ID)
+		assert.True(t, d.Confirmed)
+		assert.EqualValues(t, 2, d.Confirmations)
+	}
+
+	cancel()
+	assert.True(t, errors.Is(<-done, context.Canceled))
+	assert.Len(t, deposits, 0)
+}
+
+func TestDepositWatcherHandledOnce(t *testing.T) {
+	exchange, user := ntypes.AccAddress("exchange"), ntypes.AccAddress("user")
+	coins := ntypes.Coins{{Denom: "BNB", Amount: 5}}
+	m := msg.SendMsg{Inputs: []msg.Input{msg.NewInput(user, coins)}, Outputs: []msg.Output{msg.NewOutput(exchange, coins)}}
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: []msg.Msg{m}})
+	assert.NoError(t, err)
+
+	for _, pending := range []bool{false, true} {
+		c := &fakeDepositClient{latest: 10, txs: make(map[int64][]*ResultTx), blocks: make(chan types.EventDataNewBlock)}
+		c.txs[9] = []*ResultTx{{Hash: []byte("a"), Height: 9, Tx: bz}}
+
+		// the handler follows the doc of DepositHandler
+		type key struct {
+			ID        string
+			Confirmed bool
+		}
+		handled := make(map[key]bool)
+		var credited, notified int64
+		seenPending := make(chan struct{}, 10)
+		run := func(whileRunning func()) {
+			w := NewDepositWatcher(c, DepositOptions{FromHeight: 9, Confirmations: 2, Pending: pending, PollInterval: time.Hour})
+			w.Watch(exchange)
+			confirmed := make(chan struct{}, 10)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() {
+				done <- w.Run(ctx, func(ctx context.Context, deposit Deposit) error {
+					if deposit.Confirmed {
+						defer func() { confirmed <- struct{}{} }()
+					}
+					k := key{deposit.ID, deposit.Confirmed}
+					if handled[k] {
+						return nil
+					}
+					handled[k] = true
+					if deposit.Confirmed {
+						credited += deposit.Coins.AmountOf("BNB")
+					} else {
+						notified++
+						seenPending <- struct{}{}
+					}
+					return nil
+				})
+			}()
+			whileRunning()
+			select {
+			case <-confirmed:
+			case <-time.After(time.Second):
+				t.Fatal("no confirmed deposit")
+			}
+			cancel()
+			<-done
+		}
+		run(func() {
+			if pending {
+				<-seenPending
+			}
+			c.commit()
+		})
+		// restarting from the same height hands the deposit again
+		run(func() {})
+		assert.EqualValues(t, 5, credited, "pending %v", pending)
+		if pending {
+			assert.EqualValues(t, 1, notified)
+		} else {
+			assert.EqualValues(t, 0, notified)
+		}
+	}
+}
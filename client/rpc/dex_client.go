@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -35,6 +36,7 @@ const (
 	TimeLockMsgRoute    = "timelock"
 	AtomicSwapStoreName = "atomic_swap"
 
+	// Deprecated: match the errors returned by queries with errors.Is(err, tx.ErrNotFound).
 	TimeLockrcNotFoundErrorCode = 458760
 )
 
@@ -95,16 +97,48 @@ type DexClient interface {
 	SubmitProposal(title string, description string, proposalType msg.ProposalKind, initialDeposit types.Coins, votingPeriod time.Duration, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	Deposit(proposalID int64, amount types.Coins, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
 	Vote(proposalID int64, option msg.VoteOption, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+
+	BroadcastCtx(ctx context.Context, m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+	TxInfoSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) ([]Info, error)
+	ListAllTokensCtx(ctx context.Context, offset int, limit int) ([]types.Token, error)
+	GetTokenInfoCtx(ctx context.Context, symbol string) (*types.Token, error)
+	GetAccountCtx(ctx context.Context, addr types.AccAddress) (acc types.Account, err error)
+	GetCommitAccountCtx(ctx context.Context, addr types.AccAddress) (acc types.Account, err error)
+	GetBalancesCtx(ctx context.Context, addr types.AccAddress) ([]types.TokenBalance, error)
+	GetBalanceCtx(ctx context.Context, addr types.AccAddress, symbol string) (*types.TokenBalance, error)
+	GetFeeCtx(ctx context.Context) ([]types.FeeParam, error)
+	GetProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64) ([]types.Proposal, error)
+	GetSideChainProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64, sideChainId string) ([]types.Proposal, error)
+	GetSideChainProposalCtx(ctx context.Context, proposalId int64, sideChainId string) (types.Proposal, error)
+	GetProposalCtx(ctx context.Context, proposalId int64) (types.Proposal, error)
+	GetTimelocksCtx(ctx context.Context, addr types.AccAddress) ([]types.TimeLockRecord, error)
+	GetTimelockCtx(ctx context.Context, addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error)
+	GetSwapByIDCtx(ctx context.Context, swapID types.SwapBytes) (types.AtomicSwap, error)
+	GetSwapByCreatorCtx(ctx context.Context, creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error)
+	GetSwapByRecipientCtx(ctx context.Context, recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error)
+	GetSideChainParamsCtx(ctx context.Context, sideChainId string) ([]msg.SCParam, error)
+	ListAllMiniTokensCtx(ctx context.Context, offset int, limit int) ([]types.MiniToken, error)
+	GetMiniTokenInfoCtx(ctx context.Context, symbol string) (*types.MiniToken, error)
+	GetProphecyCtx(ctx context.Context, chainId sdk.IbcChainID, sequence int64) (*msg.Prophecy, error)
+	GetCurrentOracleSequenceCtx(ctx context.Context, chainId sdk.IbcChainID) (int64, error)
 }
 
 func (c *HTTP) TxInfoSearch(query string, prove bool, page, perPage int) ([]Info, error) {
+	return c.TxInfoSearchCtx(context.Background(), query, prove, page, perPage)
+}
+
+func (c *HTTP) TxInfoSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) ([]Info, error) {
 	if err := ValidateTxSearchQueryStr(query); err != nil {
 		return nil, err
 	}
-	return c.WSEvents.TxInfoSearch(query, prove, page, perPage)
+	return c.WSEvents.TxInfoSearchCtx(ctx, query, prove, page, perPage)
 }
 
 func (c *HTTP) ListAllTokens(offset int, limit int) ([]types.Token, error) {
+	return c.ListAllTokensCtx(context.Background(), offset, limit)
+}
+
+func (c *HTTP) ListAllTokensCtx(ctx context.Context, offset int, limit int) ([]types.Token, error) {
 	if err := ValidateOffset(offset); err != nil {
 		return nil, err
 	}
@@ -112,12 +146,12 @@ func (c *HTTP) ListAllTokens(offset int, limit int) ([]types.Token, error) {
 		return nil, err
 	}
 	path := fmt.Sprintf("tokens/list/%d/%d", offset, limit)
-	result, err := c.ABCIQuery(path, nil)
+	result, err := c.ABCIQueryCtx(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, tx.NewABCIError(result.Response.Code, result.Response.Log)
 	}
 	bz := result.Response.GetValue()
 	tokens := make([]types.Token, 0)
@@ -126,16 +160,20 @@ func (c *HTTP) ListAllTokens(offset int, limit int) ([]types.Token, error) {
 }
 
 func (c *HTTP) GetTokenInfo(symbol string) (*types.Token, error) {
+	return c.GetTokenInfoCtx(context.Background(), symbol)
+}
+
+func (c *HTTP) GetTokenInfoCtx(ctx context.Context, symbol string) (*types.Token, error) {
 	if err := ValidateSymbol(symbol); err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("tokens/info/%s", symbol)
-	result, err := c.ABCIQuery(path, nil)
+	result, err := c.ABCIQueryCtx(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, tx.NewABCIError(result.Response.Code, result.Response.Log)
 	}
 	bz := result.Response.GetValue()
 	token := new(types.Token)
@@ -153,8 +191,12 @@ func (c *HTTP) GetTokenInfo(symbol string) (*types.Token, error) {
 // 2. height: 999. the state do not exist
 // 3. GetCommitAccount will return accountA(balance: 10BNB, sequence: 10).
 func (c *HTTP) GetCommitAccount(addr types.AccAddress) (acc types.Account, err error) {
+	return c.GetCommitAccountCtx(context.Background(), addr)
+}
+
+func (c *HTTP) GetCommitAccountCtx(ctx context.Context, addr types.AccAddress) (acc types.Account, err error) {
 	key := append([]byte("account:"), addr.Bytes()...)
-	bz, err := c.QueryStore(key, AccountStoreName)
+	bz, err := c.QueryStoreCtx(ctx, key, AccountStoreName)
 	if err != nil {
 		return nil, err
 	}
@@ -174,14 +216,22 @@ func (c *HTTP) GetCommitAccount(addr types.AccAddress) (acc types.Account, err e
 // 1. AccountA(Balance: 10BNB, sequence: 1), AccountB(Balance: 5BNB, sequence: 1)
 // 2. Node receive Tx(AccountA --> AccountB 2BNB) and check have passed, but not included in block yet.
 // 3. GetAccount will return AccountA(Balance: 8BNB, sequence: 2), AccountB(Balance: 7BNB, sequence: 1)
+// With a verifier set, the committed account is returned instead, see SetVerifier.
 func (c *HTTP) GetAccount(addr types.AccAddress) (acc types.Account, err error) {
-	result, err := c.ABCIQuery(fmt.Sprintf("/account/%s", addr.String()), nil)
+	return c.GetAccountCtx(context.Background(), addr)
+}
+
+func (c *HTTP) GetAccountCtx(ctx context.Context, addr types.AccAddress) (acc types.Account, err error) {
+	if c.verifier != nil {
+		return c.GetCommitAccountCtx(ctx, addr)
+	}
+	result, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("/account/%s", addr.String()), nil)
 	if err != nil {
 		return nil, err
 	}
 	resp := result.Response
 	if !resp.IsOK() {
-		return nil, errors.New(resp.Log)
+		return nil, tx.NewABCIError(resp.Code, resp.Log)
 	}
 	value := result.Response.GetValue()
 	if len(value) == 0 {
@@ -195,7 +245,11 @@ func (c *HTTP) GetAccount(addr types.AccAddress) (acc types.Account, err error)
 }
 
 func (c *HTTP) GetBalances(addr types.AccAddress) ([]types.TokenBalance, error) {
-	account, err := c.GetAccount(addr)
+	return c.GetBalancesCtx(context.Background(), addr)
+}
+
+func (c *HTTP) GetBalancesCtx(ctx context.Context, addr types.AccAddress) ([]types.TokenBalance, error) {
+	account, err := c.GetAccountCtx(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -226,14 +280,21 @@ func (c *HTTP) GetBalances(addr types.AccAddress) ([]types.TokenBalance, error)
 }
 
 func (c *HTTP) GetBalance(addr types.AccAddress, symbol string) (*types.TokenBalance, error) {
+	return c.GetBalanceCtx(context.Background(), addr, symbol)
+}
+
+func (c *HTTP) GetBalanceCtx(ctx context.Context, addr types.AccAddress, symbol string) (*types.TokenBalance, error) {
 	if err := ValidateSymbol(symbol); err != nil {
 		return nil, err
 	}
-	exist := c.existsCC(symbol)
+	exist, err := c.existsCC(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
 	if !exist {
-		return nil, errors.New("symbol not found")
+		return nil, fmt.Errorf("symbol not found: %w", tx.ErrUnknownToken)
 	}
-	acc, err := c.GetAccount(addr)
+	acc, err := c.GetAccountCtx(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -260,12 +321,16 @@ func (c *HTTP) GetBalance(addr types.AccAddress, symbol string) (*types.TokenBal
 }
 
 func (c *HTTP) GetFee() ([]types.FeeParam, error) {
-	rawFee, err := c.ABCIQuery(fmt.Sprintf("%s/fees", ParamABCIPrefix), nil)
+	return c.GetFeeCtx(context.Background())
+}
+
+func (c *HTTP) GetFeeCtx(ctx context.Context) ([]types.FeeParam, error) {
+	rawFee, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("%s/fees", ParamABCIPrefix), nil)
 	if err != nil {
 		return nil, err
 	}
 	if !rawFee.Response.IsOK() {
-		return nil, fmt.Errorf(rawFee.Response.Log)
+		return nil, tx.NewABCIError(rawFee.Response.Code, rawFee.Response.Log)
 	}
 	var fees []types.FeeParam
 	err = c.cdc.UnmarshalBinaryLengthPrefixed(rawFee.Response.GetValue(), &fees)
@@ -273,6 +338,10 @@ func (c *HTTP) GetFee() ([]types.FeeParam, error) {
 }
 
 func (c *HTTP) GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, error) {
+	return c.GetTimelocksCtx(context.Background(), addr)
+}
+
+func (c *HTTP) GetTimelocksCtx(ctx context.Context, addr types.AccAddress) ([]types.TimeLockRecord, error) {
 
 	params := types.QueryTimeLocksParams{
 		Account: addr,
@@ -281,19 +350,19 @@ func (c *HTTP) GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, erro
 	bz, err := c.cdc.MarshalJSON(params)
 
 	if err != nil {
-		fmt.Errorf("marshal params failed %v", err)
+		return nil, fmt.Errorf("marshal params failed %v", err)
 	}
 
-	rawRecords, err := c.ABCIQuery(fmt.Sprintf("custom/%s/%s", TimeLockMsgRoute, "timelocks"), bz)
+	rawRecords, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("custom/%s/%s", TimeLockMsgRoute, "timelocks"), bz)
 
 	if err != nil {
 		return nil, err
 	}
 	if rawRecords == nil {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	if !rawRecords.Response.IsOK() {
-		return nil, fmt.Errorf(rawRecords.Response.Log)
+		return nil, tx.NewABCIError(rawRecords.Response.Code, rawRecords.Response.Log)
 	}
 	records := make([]types.TimeLockRecord, 0)
 
@@ -306,6 +375,10 @@ func (c *HTTP) GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, erro
 }
 
 func (c *HTTP) GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error) {
+	return c.GetTimelockCtx(context.Background(), addr, recordID)
+}
+
+func (c *HTTP) GetTimelockCtx(ctx context.Context, addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error) {
 
 	params := types.QueryTimeLockParams{
 		Account: addr,
@@ -318,13 +391,15 @@ func (c *HTTP) GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLo
 		return nil, fmt.Errorf("incorrectly formatted request data %s", err.Error())
 	}
 
-	rawRecord, err := c.ABCIQuery(fmt.Sprintf("custom/%s/%s", TimeLockMsgRoute, "timelock"), bz)
+	rawRecord, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("custom/%s/%s", TimeLockMsgRoute, "timelock"), bz)
 
 	if err != nil {
 		return nil, fmt.Errorf("error query %s", err.Error())
 	}
-	if rawRecord.Response.Code == TimeLockrcNotFoundErrorCode {
+	if err := tx.NewABCIError(rawRecord.Response.Code, rawRecord.Response.Log); errors.Is(err, tx.ErrNotFound) {
 		return nil, nil
+	} else if err != nil {
+		return nil, err
 	}
 	var record types.TimeLockRecord
 
@@ -338,14 +413,22 @@ func (c *HTTP) GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLo
 }
 
 func (c *HTTP) GetProposals(status types.ProposalStatus, numLatest int64) ([]types.Proposal, error) {
-	return c.getProposals(status, "", numLatest)
+	return c.GetProposalsCtx(context.Background(), status, numLatest)
+}
+
+func (c *HTTP) GetProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64) ([]types.Proposal, error) {
+	return c.getProposals(ctx, status, "", numLatest)
 }
 
 func (c *HTTP) GetSideChainProposals(status types.ProposalStatus, numLatest int64, sideChainId string) ([]types.Proposal, error) {
-	return c.getProposals(status, sideChainId, numLatest)
+	return c.GetSideChainProposalsCtx(context.Background(), status, numLatest, sideChainId)
+}
+
+func (c *HTTP) GetSideChainProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64, sideChainId string) ([]types.Proposal, error) {
+	return c.getProposals(ctx, status, sideChainId, numLatest)
 }
 
-func (c *HTTP) getProposals(status types.ProposalStatus, sideChainId string, numLatest int64) ([]types.Proposal, error) {
+func (c *HTTP) getProposals(ctx context.Context, status types.ProposalStatus, sideChainId string, numLatest int64) ([]types.Proposal, error) {
 	params := types.QueryProposalsParams{}
 	if status != types.StatusNil {
 		params.ProposalStatus = status
@@ -359,12 +442,12 @@ func (c *HTTP) getProposals(status types.ProposalStatus, sideChainId string, num
 	if err != nil {
 		return nil, err
 	}
-	rawProposals, err := c.ABCIQuery("custom/gov/proposals", bz)
+	rawProposals, err := c.ABCIQueryCtx(ctx, "custom/gov/proposals", bz)
 	if err != nil {
 		return nil, err
 	}
 	if !rawProposals.Response.IsOK() {
-		return nil, fmt.Errorf(rawProposals.Response.Log)
+		return nil, tx.NewABCIError(rawProposals.Response.Code, rawProposals.Response.Log)
 	}
 	proposals := make([]types.Proposal, 0)
 
@@ -373,14 +456,22 @@ func (c *HTTP) getProposals(status types.ProposalStatus, sideChainId string, num
 }
 
 func (c *HTTP) GetProposal(proposalId int64) (types.Proposal, error) {
-	return c.getProposal(proposalId, "")
+	return c.GetProposalCtx(context.Background(), proposalId)
+}
+
+func (c *HTTP) GetProposalCtx(ctx context.Context, proposalId int64) (types.Proposal, error) {
+	return c.getProposal(ctx, proposalId, "")
 }
 
 func (c *HTTP) GetSideChainProposal(proposalId int64, sideChainId string) (types.Proposal, error) {
-	return c.getProposal(proposalId, sideChainId)
+	return c.GetSideChainProposalCtx(context.Background(), proposalId, sideChainId)
+}
+
+func (c *HTTP) GetSideChainProposalCtx(ctx context.Context, proposalId int64, sideChainId string) (types.Proposal, error) {
+	return c.getProposal(ctx, proposalId, sideChainId)
 }
 
-func (c *HTTP) getProposal(proposalId int64, sideChainId string) (types.Proposal, error) {
+func (c *HTTP) getProposal(ctx context.Context, proposalId int64, sideChainId string) (types.Proposal, error) {
 	params := types.QueryProposalParams{
 		ProposalID: proposalId,
 	}
@@ -389,12 +480,12 @@ func (c *HTTP) getProposal(proposalId int64, sideChainId string) (types.Proposal
 	if err != nil {
 		return nil, err
 	}
-	rawProposal, err := c.ABCIQuery("custom/gov/proposal", bz)
+	rawProposal, err := c.ABCIQueryCtx(ctx, "custom/gov/proposal", bz)
 	if err != nil {
 		return nil, err
 	}
 	if !rawProposal.Response.IsOK() {
-		return nil, fmt.Errorf(rawProposal.Response.Log)
+		return nil, tx.NewABCIError(rawProposal.Response.Code, rawProposal.Response.Log)
 	}
 	var proposal types.Proposal
 
@@ -403,42 +494,54 @@ func (c *HTTP) getProposal(proposalId int64, sideChainId string) (types.Proposal
 }
 
 func (c *HTTP) GetSideChainParams(sideChainId string) ([]msg.SCParam, error) {
+	return c.GetSideChainParamsCtx(context.Background(), sideChainId)
+}
+
+func (c *HTTP) GetSideChainParamsCtx(ctx context.Context, sideChainId string) ([]msg.SCParam, error) {
 	data, err := c.cdc.MarshalJSON(sideChainId)
 	if err != nil {
 		return nil, err
 	}
-	rawParams, err := c.ABCIQuery("param/sideParams", data)
+	rawParams, err := c.ABCIQueryCtx(ctx, "param/sideParams", data)
 	if err != nil {
 		return nil, err
 	}
 	if !rawParams.Response.IsOK() {
-		return nil, fmt.Errorf(rawParams.Response.Log)
+		return nil, tx.NewABCIError(rawParams.Response.Code, rawParams.Response.Log)
 	}
 	var params []msg.SCParam
 	err = c.cdc.UnmarshalJSON(rawParams.Response.GetValue(), &params)
 	return params, err
 }
 
-func (c *HTTP) existsCC(symbol string) bool {
-	resp, err := c.ABCIQuery(fmt.Sprintf("tokens/info/%s", symbol), nil)
+// existsCC reports whether the token symbol is issued. The error is set when the node
+// can't tell, e.g. it can't be reached or the query fails for another reason.
+func (c *HTTP) existsCC(ctx context.Context, symbol string) (bool, error) {
+	resp, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("tokens/info/%s", symbol), nil)
 	if err != nil {
-		return false
+		return false, err
 	}
-	if !resp.Response.IsOK() {
-		return false
+	if err := tx.NewABCIError(resp.Response.Code, resp.Response.Log); err != nil {
+		if errors.Is(err, tx.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return false
+		return false, nil
 	}
 	var token types.Token
-	err = c.cdc.UnmarshalBinaryLengthPrefixed(resp.Response.GetValue(), &token)
-	if err != nil {
-		return false
+	if err := c.cdc.UnmarshalBinaryLengthPrefixed(resp.Response.GetValue(), &token); err != nil {
+		return false, err
 	}
-	return true
+	return true, nil
 }
 
 func (c *HTTP) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
+	return c.GetSwapByIDCtx(context.Background(), swapID)
+}
+
+func (c *HTTP) GetSwapByIDCtx(ctx context.Context, swapID types.SwapBytes) (types.AtomicSwap, error) {
 	params := types.QuerySwapByID{
 		SwapID: swapID,
 	}
@@ -447,15 +550,15 @@ func (c *HTTP) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
 		return types.AtomicSwap{}, err
 	}
 
-	resp, err := c.ABCIQuery(fmt.Sprintf("custom/%s/%s", "atomicSwap", "swapid"), bz)
+	resp, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("custom/%s/%s", "atomicSwap", "swapid"), bz)
 	if err != nil {
 		return types.AtomicSwap{}, err
 	}
 	if !resp.Response.IsOK() {
-		return types.AtomicSwap{}, fmt.Errorf(resp.Response.Log)
+		return types.AtomicSwap{}, tx.NewABCIError(resp.Response.Code, resp.Response.Log)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return types.AtomicSwap{}, fmt.Errorf("zero records")
+		return types.AtomicSwap{}, ZeroRecordsError
 	}
 	var result types.AtomicSwap
 	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &result)
@@ -466,6 +569,10 @@ func (c *HTTP) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
 }
 
 func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	return c.GetSwapByCreatorCtx(context.Background(), creatorAddr, offset, limit)
+}
+
+func (c *HTTP) GetSwapByCreatorCtx(ctx context.Context, creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
 	addr, err := types.AccAddressFromBech32(creatorAddr)
 	if err != nil {
 		return nil, err
@@ -482,15 +589,15 @@ func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) (
 		return nil, err
 	}
 
-	resp, err := c.ABCIQuery(fmt.Sprintf("custom/%s/%s", "atomicSwap", "swapcreator"), bz)
+	resp, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("custom/%s/%s", "atomicSwap", "swapcreator"), bz)
 	if err != nil {
 		return nil, err
 	}
 	if !resp.Response.IsOK() {
-		return nil, fmt.Errorf(resp.Response.Log)
+		return nil, tx.NewABCIError(resp.Response.Code, resp.Response.Log)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	var swapIDList []types.SwapBytes
 	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &swapIDList)
@@ -501,6 +608,10 @@ func (c *HTTP) GetSwapByCreator(creatorAddr string, offset int64, limit int64) (
 }
 
 func (c *HTTP) GetSwapByRecipient(recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	return c.GetSwapByRecipientCtx(context.Background(), recipientAddr, offset, limit)
+}
+
+func (c *HTTP) GetSwapByRecipientCtx(ctx context.Context, recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
 	recipient, err := types.AccAddressFromBech32(recipientAddr)
 	if err != nil {
 		return nil, err
@@ -516,15 +627,15 @@ func (c *HTTP) GetSwapByRecipient(recipientAddr string, offset int64, limit int6
 		return nil, err
 	}
 
-	resp, err := c.ABCIQuery(fmt.Sprintf("custom/%s/%s", "atomicSwap", "swaprecipient"), bz)
+	resp, err := c.ABCIQueryCtx(ctx, fmt.Sprintf("custom/%s/%s", "atomicSwap", "swaprecipient"), bz)
 	if err != nil {
 		return nil, err
 	}
 	if !resp.Response.IsOK() {
-		return nil, fmt.Errorf(resp.Response.Log)
+		return nil, tx.NewABCIError(resp.Response.Code, resp.Response.Log)
 	}
 	if len(resp.Response.GetValue()) == 0 {
-		return nil, fmt.Errorf("zero records")
+		return nil, ZeroRecordsError
 	}
 	var swapIDList []types.SwapBytes
 	err = c.cdc.UnmarshalJSON(resp.Response.GetValue(), &swapIDList)
@@ -535,6 +646,10 @@ func (c *HTTP) GetSwapByRecipient(recipientAddr string, offset int64, limit int6
 }
 
 func (c *HTTP) ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, error) {
+	return c.ListAllMiniTokensCtx(context.Background(), offset, limit)
+}
+
+func (c *HTTP) ListAllMiniTokensCtx(ctx context.Context, offset int, limit int) ([]types.MiniToken, error) {
 	if err := ValidateOffset(offset); err != nil {
 		return nil, err
 	}
@@ -542,12 +657,12 @@ func (c *HTTP) ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, erro
 		return nil, err
 	}
 	path := fmt.Sprintf("mini-tokens/list/%d/%d", offset, limit)
-	result, err := c.ABCIQuery(path, nil)
+	result, err := c.ABCIQueryCtx(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, tx.NewABCIError(result.Response.Code, result.Response.Log)
 	}
 	bz := result.Response.GetValue()
 	tokens := make([]types.MiniToken, 0)
@@ -556,16 +671,20 @@ func (c *HTTP) ListAllMiniTokens(offset int, limit int) ([]types.MiniToken, erro
 }
 
 func (c *HTTP) GetMiniTokenInfo(symbol string) (*types.MiniToken, error) {
+	return c.GetMiniTokenInfoCtx(context.Background(), symbol)
+}
+
+func (c *HTTP) GetMiniTokenInfoCtx(ctx context.Context, symbol string) (*types.MiniToken, error) {
 	if err := ValidateSymbol(symbol); err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("mini-tokens/info/%s", symbol)
-	result, err := c.ABCIQuery(path, nil)
+	result, err := c.ABCIQueryCtx(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if !result.Response.IsOK() {
-		return nil, fmt.Errorf(result.Response.Log)
+		return nil, tx.NewABCIError(result.Response.Code, result.Response.Log)
 	}
 	bz := result.Response.GetValue()
 	token := new(types.MiniToken)
@@ -833,21 +952,95 @@ func (c *HTTP) Unbind(symbol string, syncType SyncType, options ...tx.Option) (*
 }
 
 func (c *HTTP) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
-	signBz, err := c.sign(m, options...)
+	return c.BroadcastCtx(context.Background(), m, syncType, options...)
+}
+
+func (c *HTTP) BroadcastCtx(ctx context.Context, m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	return c.BroadcastMsgsCtx(ctx, []msg.Msg{m}, syncType, options...)
+}
+
+// BroadcastMsgs signs all msgs into a single tx and broadcasts it.
+func (c *HTTP) BroadcastMsgs(msgs []msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	return c.BroadcastMsgsCtx(context.Background(), msgs, syncType, options...)
+}
+
+func (c *HTTP) BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	signBz, done, err := c.sign(ctx, msgs, options...)
 	if err != nil {
 		return nil, err
 	}
+	return c.broadcastSigned(ctx, signBz, syncType, done)
+}
+
+// BuildUnsigned prepares an unsigned tx of msgs for keys.SignOffline. The account number
+// and sequence of the first signer are queried unless given by options, no key is needed.
+func (c *HTTP) BuildUnsigned(msgs []msg.Msg, options ...tx.Option) ([]byte, error) {
+	return c.BuildUnsignedCtx(context.Background(), msgs, options...)
+}
+
+func (c *HTTP) BuildUnsignedCtx(ctx context.Context, msgs []msg.Msg, options ...tx.Option) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msg to sign")
+	}
+	signers := msgs[0].GetSigners()
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("msg %s has no signer", msgs[0].Type())
+	}
+	signMsg := tx.NewSignMsg(networkChainID(), msgs, options...)
+	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
+		accNum, seq, err := c.fetchAccount(ctx, signers[0])
+		if err != nil {
+			return nil, err
+		}
+		signMsg.AccountNumber, signMsg.Sequence = accNum, seq
+	}
+	if err := tx.SetOrderIds(signMsg, signers[0]); err != nil {
+		return nil, err
+	}
+	for _, m := range signMsg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return tx.EncodeUnsignedTx(*signMsg)
+}
+
+// BroadcastSigned broadcasts a tx returned by keys.SignOffline.
+func (c *HTTP) BroadcastSigned(signed []byte, syncType SyncType) (*core_types.ResultBroadcastTx, error) {
+	return c.BroadcastSignedCtx(context.Background(), signed, syncType)
+}
+
+func (c *HTTP) BroadcastSignedCtx(ctx context.Context, signed []byte, syncType SyncType) (*core_types.ResultBroadcastTx, error) {
+	signBz, err := tx.SignedTxBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+	return c.broadcastSigned(ctx, signBz, syncType, func(error) {})
+}
+
+// broadcastSigned broadcasts signBz and reports the CheckTx outcome to done.
+func (c *HTTP) broadcastSigned(ctx context.Context, signBz []byte, syncType SyncType, done func(error)) (*core_types.ResultBroadcastTx, error) {
 	switch syncType {
 	case Async:
-		return c.BroadcastTxAsync(signBz)
+		res, err := c.BroadcastTxAsyncCtx(ctx, signBz)
+		done(err)
+		return res, err
 	case Sync:
-		return c.BroadcastTxSync(signBz)
+		res, err := c.BroadcastTxSyncCtx(ctx, signBz)
+		if err == nil && res.Code != 0 {
+			done(tx.NewABCIError(res.Code, res.Log))
+		} else {
+			done(err)
+		}
+		return res, err
 	case Commit:
-		commitRes, err := c.BroadcastTxCommit(signBz)
+		commitRes, err := c.BroadcastTxCommitCtx(ctx, signBz)
 		if err != nil {
+			done(err)
 			return nil, err
 		}
 		if commitRes.CheckTx.IsErr() {
+			done(tx.NewABCIError(commitRes.CheckTx.Code, commitRes.CheckTx.Log))
 			return &core_types.ResultBroadcastTx{
 				Code: commitRes.CheckTx.Code,
 				Log:  commitRes.CheckTx.Log,
@@ -855,6 +1048,7 @@ func (c *HTTP) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*c
 				Data: commitRes.CheckTx.Data,
 			}, nil
 		}
+		done(nil)
 		return &core_types.ResultBroadcastTx{
 			Code: commitRes.DeliverTx.Code,
 			Log:  commitRes.DeliverTx.Log,
@@ -862,6 +1056,7 @@ func (c *HTTP) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*c
 			Data: commitRes.DeliverTx.Data,
 		}, nil
 	default:
+		done(fmt.Errorf("unknown synctype"))
 		return nil, fmt.Errorf("unknown synctype")
 	}
 }
@@ -879,8 +1074,12 @@ func (c *HTTP) Claim(chainId sdk.IbcChainID, sequence uint64, payload []byte, sy
 }
 
 func (c *HTTP) GetLastTotalPower() (power *int64, err error) {
+	return c.GetLastTotalPowerCtx(context.Background())
+}
+
+func (c *HTTP) GetLastTotalPowerCtx(ctx context.Context) (power *int64, err error) {
 	key := []byte{0x12}
-	bz, err := c.QueryStore(key, StakeStoreKey)
+	bz, err := c.QueryStoreCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return
 	}
@@ -889,8 +1088,12 @@ func (c *HTTP) GetLastTotalPower() (power *int64, err error) {
 }
 
 func (c *HTTP) GetOracleRelayers() (relayers []msg.OracleRelayer, err error) {
+	return c.GetOracleRelayersCtx(context.Background())
+}
+
+func (c *HTTP) GetOracleRelayersCtx(ctx context.Context) (relayers []msg.OracleRelayer, err error) {
 	key := []byte{0x03}
-	bz, err := c.QueryStore(key, StakeStoreKey)
+	bz, err := c.QueryStoreCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return
 	}
@@ -899,8 +1102,12 @@ func (c *HTTP) GetOracleRelayers() (relayers []msg.OracleRelayer, err error) {
 }
 
 func (c *HTTP) GetProphecy(chainId sdk.IbcChainID, sequence int64) (*msg.Prophecy, error) {
+	return c.GetProphecyCtx(context.Background(), chainId, sequence)
+}
+
+func (c *HTTP) GetProphecyCtx(ctx context.Context, chainId sdk.IbcChainID, sequence int64) (*msg.Prophecy, error) {
 	key := []byte(msg.GetClaimId(cTypes.ChainID(chainId), msg.OracleChannelId, uint64(sequence)))
-	bz, err := c.QueryStore(key, OracleStoreName)
+	bz, err := c.QueryStoreCtx(ctx, key, OracleStoreName)
 	if err != nil {
 		return nil, err
 	}
@@ -923,8 +1130,12 @@ func (c *HTTP) GetProphecy(chainId sdk.IbcChainID, sequence int64) (*msg.Prophec
 }
 
 func (c *HTTP) GetCurrentOracleSequence(chainId sdk.IbcChainID) (int64, error) {
+	return c.GetCurrentOracleSequenceCtx(context.Background(), chainId)
+}
+
+func (c *HTTP) GetCurrentOracleSequenceCtx(ctx context.Context, chainId sdk.IbcChainID) (int64, error) {
 	key := types.GetReceiveSequenceKey(chainId, msg.OracleChannelId)
-	bz, err := c.QueryStore(key, SideChainStoreName)
+	bz, err := c.QueryStoreCtx(ctx, key, SideChainStoreName)
 	if err != nil {
 		return 0, err
 	}
@@ -936,55 +1147,78 @@ func (c *HTTP) GetCurrentOracleSequence(chainId sdk.IbcChainID) (int64, error) {
 	return int64(sequence), err
 }
 
-func (c *HTTP) sign(m msg.Msg, options ...tx.Option) ([]byte, error) {
+// sign returns the signed tx along with a callback to report the CheckTx outcome
+// to the sequence manager, if the sequence was taken from it.
+func (c *HTTP) sign(ctx context.Context, msgs []msg.Msg, options ...tx.Option) ([]byte, func(error), error) {
+	noop := func(error) {}
 	if c.key == nil {
-		return nil, fmt.Errorf("keymanager is missing, use SetKeyManager to set key")
+		return nil, noop, fmt.Errorf("keymanager is missing, use SetKeyManager to set key")
 	}
-	// prepare message to sign
-	chainID := gtypes.ProdChainID
-	if types.Network == types.TestNetwork {
-		chainID = gtypes.TestnetChainID
-	} else if types.Network == types.TmpTestNetwork {
-		chainID = gtypes.KongoChainId
-	} else if types.Network == types.GangesNetwork {
-		chainID = gtypes.GangesChainId
-	}
-	signMsg := &tx.StdSignMsg{
-		ChainID:       chainID,
-		AccountNumber: -1,
-		Sequence:      -1,
-		Memo:          "",
-		Msgs:          []msg.Msg{m},
-		Source:        tx.Source,
-	}
-
-	for _, op := range options {
-		signMsg = op(signMsg)
+	if len(msgs) == 0 {
+		return nil, noop, fmt.Errorf("no msg to sign")
 	}
+	signMsg := tx.NewSignMsg(networkChainID(), msgs, options...)
 
+	done := noop
 	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
 		fromAddr := c.key.GetAddr()
-		acc, err := c.GetAccount(fromAddr)
+		fetch := c.fetchAccount
+		if c.seqManager != nil {
+			fetch = func(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+				return c.seqManager.Next(ctx, addr, c.fetchAccount)
+			}
+		}
+		accNum, seq, err := fetch(ctx, fromAddr)
 		if err != nil {
-			return nil, err
+			return nil, noop, err
 		}
-		if acc == nil {
-			return nil, fmt.Errorf("the signer account do not exist in the chain")
+		signMsg.AccountNumber, signMsg.Sequence = accNum, seq
+		if c.seqManager != nil {
+			done = func(err error) {
+				c.seqManager.Done(fromAddr, seq, err)
+			}
 		}
-		signMsg.Sequence = acc.GetSequence()
-		signMsg.AccountNumber = acc.GetAccountNumber()
 	}
 
-	// special logic for createOrder, to save account query
-	if orderMsg, ok := m.(msg.CreateOrderMsg); ok {
-		orderMsg.Id = msg.GenerateOrderID(signMsg.Sequence+1, c.key.GetAddr())
-		signMsg.Msgs[0] = orderMsg
+	if err := tx.SetOrderIds(signMsg, c.key.GetAddr()); err != nil {
+		done(err)
+		return nil, noop, err
 	}
 
 	for _, m := range signMsg.Msgs {
 		if err := m.ValidateBasic(); err != nil {
-			return nil, err
+			done(err)
+			return nil, noop, err
 		}
 	}
-	return c.key.Sign(*signMsg)
+	signBz, err := c.key.Sign(*signMsg)
+	if err != nil {
+		done(err)
+		return nil, noop, err
+	}
+	return signBz, done, nil
+}
+
+// networkChainID returns the chain id of the network set by types.Network.
+func networkChainID() string {
+	switch types.Network {
+	case types.TestNetwork:
+		return gtypes.TestnetChainID
+	case types.TmpTestNetwork:
+		return gtypes.KongoChainId
+	case types.GangesNetwork:
+		return gtypes.GangesChainId
+	}
+	return gtypes.ProdChainID
+}
+
+func (c *HTTP) fetchAccount(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+	acc, err := c.GetAccountCtx(ctx, addr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if acc == nil {
+		return 0, 0, fmt.Errorf("the signer account do not exist in the chain")
+	}
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
 }
@@ -0,0 +1,163 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+	"github.com/bnb-chain/go-sdk/common"
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/keys"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// FailoverClient spreads calls over several nodes. Queries go to a healthy node and are
+// retried on the next one after a transport failure, with a jittered backoff. A signed
+// tx is only sent to another node when it could not be sent at all.
+type FailoverClient struct {
+	clients []*HTTP
+	pool    *common.EndpointPool
+}
+
+// NewFailoverRPCClient connects to every node in nodeURIs, in the form tcp://<host>:<port>.
+// The first one is preferred while it is healthy.
+func NewFailoverRPCClient(nodeURIs []string, network ntypes.ChainNetwork, opts common.RetryOptions, options ...common.TransportOption) (*FailoverClient, error) {
+	if len(nodeURIs) == 0 {
+		return nil, fmt.Errorf("no node endpoint")
+	}
+	f := &FailoverClient{clients: make([]*HTTP, 0, len(nodeURIs))}
+	for _, uri := range nodeURIs {
+		f.clients = append(f.clients, NewRPCClient(uri, network, options...))
+	}
+	f.pool = common.NewEndpointPool(nodeURIs, opts, f.checkHealth)
+	return f, nil
+}
+
+func (f *FailoverClient) checkHealth(ctx context.Context, i int) (int64, bool, error) {
+	c := f.clients[i]
+	if _, err := c.HealthCtx(ctx); err != nil {
+		return 0, false, err
+	}
+	status, err := c.StatusCtx(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	return status.SyncInfo.LatestBlockHeight, status.SyncInfo.CatchingUp, nil
+}
+
+// CheckHealth checks every node now rather than waiting for the next periodic check.
+func (f *FailoverClient) CheckHealth(ctx context.Context) []common.EndpointStatus {
+	return f.pool.CheckHealth(ctx)
+}
+
+func (f *FailoverClient) Statuses() []common.EndpointStatus {
+	return f.pool.Statuses()
+}
+
+// Client returns the node calls currently go to.
+func (f *FailoverClient) Client() *HTTP {
+	return f.clients[f.pool.Pick(context.Background())]
+}
+
+func (f *FailoverClient) SetKeyManager(k keys.KeyManager) {
+	for _, c := range f.clients {
+		c.SetKeyManager(k)
+	}
+}
+
+// SetSequenceManager shares m between the nodes, so that sequences survive a failover.
+func (f *FailoverClient) SetSequenceManager(m tx.SequenceManager) {
+	for _, c := range f.clients {
+		c.SetSequenceManager(m)
+	}
+}
+
+func (f *FailoverClient) SetInterceptors(interceptors ...interceptor.Interceptor) {
+	for _, c := range f.clients {
+		c.SetInterceptors(interceptors...)
+	}
+}
+
+func (f *FailoverClient) Stop() {
+	for _, c := range f.clients {
+		c.Stop()
+	}
+}
+
+// Query runs fn against a healthy node, trying the next ones when it fails to reach
+// the node. fn must be idempotent, e.g. any Get or Query method of HTTP:
+//
+//	var acc types.Account
+//	err := f.Query(ctx, func(ctx context.Context, c *rpc.HTTP) (err error) {
+//		acc, err = c.GetAccountCtx(ctx, addr)
+//		return err
+//	})
+func (f *FailoverClient) Query(ctx context.Context, fn func(ctx context.Context, c *HTTP) error) error {
+	return f.pool.Do(ctx, isTransportError, func(i int) error {
+		return fn(ctx, f.clients[i])
+	})
+}
+
+func (f *FailoverClient) Broadcast(m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	return f.BroadcastMsgsCtx(context.Background(), []msg.Msg{m}, syncType, options...)
+}
+
+func (f *FailoverClient) BroadcastCtx(ctx context.Context, m msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	return f.BroadcastMsgsCtx(ctx, []msg.Msg{m}, syncType, options...)
+}
+
+// BroadcastMsgsCtx signs msgs once and broadcasts the signed tx. It is sent to the next
+// node only when the previous one was not connected: after a timeout the tx may have
+// reached the mempool, so the error is returned for the caller to check with Tx.
+func (f *FailoverClient) BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, syncType SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error) {
+	var signBz []byte
+	var done func(error)
+	err := f.Query(ctx, func(ctx context.Context, c *HTTP) (err error) {
+		signBz, done, err = c.sign(ctx, msgs, options...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res *core_types.ResultBroadcastTx
+	err = f.pool.Do(ctx, isNotSent, func(i int) (err error) {
+		res, err = f.clients[i].broadcastSigned(ctx, signBz, syncType, func(err error) {
+			if !isNotSent(err) {
+				done(err)
+			}
+		})
+		return err
+	})
+	if isNotSent(err) {
+		done(err)
+	}
+	return res, err
+}
+
+// isNotSent reports whether err guarantees the request never left the client.
+func isNotSent(err error) bool {
+	if errors.Is(err, ErrNotConnected) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransportError reports whether err is a failure to reach the node, rather than an
+// answer of the node.
+func isTransportError(err error) bool {
+	if isNotSent(err) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, tx.ErrUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "response channel is closed")
+}
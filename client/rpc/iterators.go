@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/common/types"
+)
+
+// TokenIterator walks the tokens of ListAllTokens, limit by limit:
+//
+//	it := c.IterateTokens(100)
+//	for it.Next() {
+//		fmt.Println(it.Token().Symbol)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type TokenIterator struct {
+	*common.Pager
+	page []types.Token
+}
+
+// IterateTokens returns an iterator over all the tokens, fetching limit tokens at a
+// time, common.DefaultPageLimit if zero.
+func (c *HTTP) IterateTokens(limit int) *TokenIterator {
+	it := &TokenIterator{}
+	it.Pager = common.NewPager(limit, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		tokens, err := c.ListAllTokensCtx(ctx, offset, limit)
+		it.page = tokens
+		return len(tokens), len(tokens) < limit, err
+	})
+	return it
+}
+
+func (it *TokenIterator) Token() types.Token {
+	return it.page[it.Index()]
+}
+
+// MiniTokenIterator walks the mini tokens of ListAllMiniTokens, see TokenIterator.
+type MiniTokenIterator struct {
+	*common.Pager
+	page []types.MiniToken
+}
+
+func (c *HTTP) IterateMiniTokens(limit int) *MiniTokenIterator {
+	it := &MiniTokenIterator{}
+	it.Pager = common.NewPager(limit, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		tokens, err := c.ListAllMiniTokensCtx(ctx, offset, limit)
+		it.page = tokens
+		return len(tokens), len(tokens) < limit, err
+	})
+	return it
+}
+
+func (it *MiniTokenIterator) MiniToken() types.MiniToken {
+	return it.page[it.Index()]
+}
+
+// SwapIterator walks the swap ids of GetSwapByCreator or GetSwapByRecipient, see
+// TokenIterator.
+type SwapIterator struct {
+	*common.Pager
+	page []types.SwapBytes
+}
+
+func (c *HTTP) IterateSwapsByCreator(creatorAddr string, limit int) *SwapIterator {
+	return newSwapIterator(limit, func(ctx context.Context, offset, limit int64) ([]types.SwapBytes, error) {
+		return c.GetSwapByCreatorCtx(ctx, creatorAddr, offset, limit)
+	})
+}
+
+func (c *HTTP) IterateSwapsByRecipient(recipientAddr string, limit int) *SwapIterator {
+	return newSwapIterator(limit, func(ctx context.Context, offset, limit int64) ([]types.SwapBytes, error) {
+		return c.GetSwapByRecipientCtx(ctx, recipientAddr, offset, limit)
+	})
+}
+
+func newSwapIterator(limit int, list func(ctx context.Context, offset, limit int64) ([]types.SwapBytes, error)) *SwapIterator {
+	it := &SwapIterator{}
+	it.Pager = common.NewPager(limit, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		if err := ValidateLimit(limit); err != nil {
+			return 0, true, err
+		}
+		swaps, err := list(ctx, int64(offset), int64(limit))
+		if err == ZeroRecordsError {
+			return 0, true, nil
+		}
+		it.page = swaps
+		return len(swaps), len(swaps) < limit, err
+	})
+	return it
+}
+
+func (it *SwapIterator) SwapID() types.SwapBytes {
+	return it.page[it.Index()]
+}
+
+// TxIterator walks the txs found by TxSearch, see TokenIterator.
+type TxIterator struct {
+	*common.Pager
+	page []*ResultTx
+}
+
+// IterateTxSearch returns an iterator over the txs matching query, fetching perPage
+// txs at a time, common.DefaultPageLimit if zero.
+func (c *HTTP) IterateTxSearch(query string, prove bool, perPage int) *TxIterator {
+	it := &TxIterator{}
+	page := 0
+	it.Pager = common.NewPager(perPage, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		if err := ValidateLimit(limit); err != nil {
+			return 0, true, err
+		}
+		page++
+		res, err := c.TxSearchCtx(ctx, query, prove, page, limit)
+		if err != nil {
+			return 0, true, err
+		}
+		it.page = res.Txs
+		// the node may return fewer txs per page than asked for
+		return len(res.Txs), offset+len(res.Txs) >= res.TotalCount, nil
+	})
+	return it
+}
+
+func (it *TxIterator) Tx() *ResultTx {
+	return it.page[it.Index()]
+}
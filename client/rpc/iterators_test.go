@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+)
+
+func TestIterateTxSearch(t *testing.T) {
+	var pages []int
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		var params struct {
+			Page    string `json:"page"`
+			PerPage string `json:"per_page"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params, &params))
+		var page int
+		fmt.Sscan(params.Page, &page)
+		pages = append(pages, page)
+		// the node returns 2 txs per page whatever is asked for
+		res := ResultTxSearch{TotalCount: 5}
+		for i := 2 * (page - 1); i < 2*page && i < 5; i++ {
+			res.Txs = append(res.Txs, &ResultTx{Height: int64(i + 1)})
+		}
+		conn.reply(req.ID.(rpctypes.JSONRPCStringID), res)
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+
+	it := c.IterateTxSearch("tx.height>0", false, 10)
+	var heights []int64
+	for it.Next() {
+		heights = append(heights, it.Tx().Height)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, heights)
+	assert.Equal(t, []int{1, 2, 3}, pages)
+
+	it = c.IterateTxSearch("tx.height>0", false, -1)
+	assert.False(t, it.Next())
+	assert.Equal(t, LimitNegativeError, it.Err())
+}
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/bnb-chain/go-sdk/client/rpc"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -66,6 +68,48 @@ func (a ABCIApp) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	return &ctypes.ResultBroadcastTx{Code: c.Code, Data: c.Data, Log: c.Log, Hash: tx.Hash()}, nil
 }
 
+func (a ABCIApp) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.ABCIInfo()
+}
+
+func (a ABCIApp) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.ABCIQuery(path, data)
+}
+
+func (a ABCIApp) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.ABCIQueryWithOptions(path, data, opts)
+}
+
+func (a ABCIApp) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.BroadcastTxCommit(tx)
+}
+
+func (a ABCIApp) BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.BroadcastTxAsync(tx)
+}
+
+func (a ABCIApp) BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.BroadcastTxSync(tx)
+}
+
 // ABCIMock will send all abci related request to the named app,
 // so you can test app behavior from a client without needing
 // an entire tendermint node
@@ -117,6 +161,48 @@ func (m ABCIMock) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error
 	return res.(*ctypes.ResultBroadcastTx), nil
 }
 
+func (m ABCIMock) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ABCIInfo()
+}
+
+func (m ABCIMock) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ABCIQuery(path, data)
+}
+
+func (m ABCIMock) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ABCIQueryWithOptions(path, data, opts)
+}
+
+func (m ABCIMock) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.BroadcastTxCommit(tx)
+}
+
+func (m ABCIMock) BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.BroadcastTxAsync(tx)
+}
+
+func (m ABCIMock) BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.BroadcastTxSync(tx)
+}
+
 // ABCIRecorder can wrap another type (ABCIApp, ABCIMock, or Client)
 // and record all ABCI related calls.
 type ABCIRecorder struct {
@@ -192,3 +278,45 @@ func (r *ABCIRecorder) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx,
 	})
 	return res, err
 }
+
+func (r *ABCIRecorder) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.ABCIInfo()
+}
+
+func (r *ABCIRecorder) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.ABCIQuery(path, data)
+}
+
+func (r *ABCIRecorder) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.ABCIQueryWithOptions(path, data, opts)
+}
+
+func (r *ABCIRecorder) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.BroadcastTxCommit(tx)
+}
+
+func (r *ABCIRecorder) BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.BroadcastTxAsync(tx)
+}
+
+func (r *ABCIRecorder) BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.BroadcastTxSync(tx)
+}
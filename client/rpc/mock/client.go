@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/bnb-chain/go-sdk/client/rpc"
@@ -135,3 +136,87 @@ func (c Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
 func (c Client) SetLogger(log.Logger) {
 	return
 }
+
+func (c Client) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Status()
+}
+
+func (c Client) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.ABCIInfo()
+}
+
+func (c Client) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.ABCIQuery(path, data)
+}
+
+func (c Client) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.ABCIQueryWithOptions(path, data, opts)
+}
+
+func (c Client) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.BroadcastTxCommit(tx)
+}
+
+func (c Client) BroadcastTxAsyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.BroadcastTxAsync(tx)
+}
+
+func (c Client) BroadcastTxSyncCtx(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.BroadcastTxSync(tx)
+}
+
+func (c Client) BlockchainInfoCtx(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.BlockchainInfo(minHeight, maxHeight)
+}
+
+func (c Client) GenesisCtx(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Genesis()
+}
+
+func (c Client) BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Block(height)
+}
+
+func (c Client) CommitCtx(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Commit(height)
+}
+
+func (c Client) ValidatorsCtx(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Validators(height)
+}
@@ -0,0 +1,630 @@
+package mock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/keys"
+	gtypes "github.com/bnb-chain/go-sdk/types"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// fakeBlockInterval is the time between two blocks of a FakeChain.
+const fakeBlockInterval = time.Second
+
+const (
+	defaultTxSearchPerPage = 30
+	maxTxSearchPerPage     = 100
+	maxBlockchainInfo      = 20
+)
+
+// FakeChain is an in-memory chain implementing rpc.Client, so that the code using
+// the SDK can be tested without a node. It keeps the accounts, tokens, timelocks and
+// atomic swaps, checks the signature, account number and sequence of the txs signed
+// by keys.KeyManager, and applies their transfer, issue, mint, burn, freeze,
+// ownership, timelock and HTLT msgs. No fee is charged.
+//
+// Every tx passing the checks is committed at once in a block of its own, whatever
+// the way it is broadcast, and the NewBlock and Tx events are sent to the
+// subscriptions, dropped when the channel is full. ProduceBlocks makes empty blocks,
+// e.g. for a swap to expire, and AdvanceTime moves the block time forward.
+//
+// The dex, governance, side chain and staking calls are not modelled, they are
+// served by DexClient and StakingClient, to be set when used.
+type FakeChain struct {
+	cmn.BaseService
+	rpc.DexClient
+	rpc.StakingClient
+
+	chainID string
+	cdc     *amino.Codec
+	key     keys.KeyManager
+
+	mtx      sync.Mutex
+	time     time.Time
+	state    *fakeState
+	blocks   []*fakeBlock
+	totalTxs int64
+	txs      map[string]*rpc.ResultTx
+	txOrder  []*rpc.ResultTx
+	subs     map[string]*fakeSubscription
+	// hooks are called with the chain locked once a block is committed
+	hooks []func(*fakeBlock)
+}
+
+var _ rpc.Client = (*FakeChain)(nil)
+
+type fakeBlock struct {
+	block   *tmtypes.Block
+	meta    *tmtypes.BlockMeta
+	results []*rpc.ResponseDeliverTx
+}
+
+type fakeSubscription struct {
+	query *query.Query
+	out   chan ctypes.ResultEvent
+}
+
+// NewFakeChain returns a chain whose txs are signed for chainID, with the native
+// token and a first empty block.
+func NewFakeChain(chainID string) *FakeChain {
+	f := &FakeChain{
+		chainID: chainID,
+		cdc:     gtypes.NewCodec(),
+		time:    time.Now().UTC().Truncate(time.Second),
+		state:   newFakeState(),
+		txs:     make(map[string]*rpc.ResultTx),
+		subs:    make(map[string]*fakeSubscription),
+	}
+	f.BaseService = *cmn.NewBaseService(nil, "FakeChain", f)
+	f.state.addToken(types.Token{Name: "Binance Chain Native Token", Symbol: gtypes.NativeSymbol, OrigSymbol: gtypes.NativeSymbol})
+	f.ProduceBlocks(1)
+	return f
+}
+
+// AddToken adds token to the chain, its total supply given to its owner.
+func (f *FakeChain) AddToken(token types.Token) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.state.addToken(token)
+	if token.TotalSupply > 0 {
+		f.state.addCoins(token.Owner, types.Coins{{Denom: token.Symbol, Amount: token.TotalSupply.ToInt64()}})
+	}
+}
+
+// Fund gives coins to addr, creating its account if needed. The coins of the tokens
+// added to the chain increase their total supply.
+func (f *FakeChain) Fund(addr types.AccAddress, coins types.Coins) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.state.addCoins(addr, coins)
+	for _, coin := range coins {
+		if token, ok := f.state.tokens[coin.Denom]; ok {
+			token.TotalSupply += types.Fixed8(coin.Amount)
+		}
+	}
+}
+
+// ProduceBlocks commits n empty blocks and returns the latest height.
+func (f *FakeChain) ProduceBlocks(n int) int64 {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for i := 0; i < n; i++ {
+		f.commitBlock(f.nextBlock(), nil, nil)
+	}
+	return int64(len(f.blocks))
+}
+
+// AdvanceTime moves the time of the next blocks d forward, e.g. to unlock timelocks.
+func (f *FakeChain) AdvanceTime(d time.Duration) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.time = f.time.Add(d)
+}
+
+func (f *FakeChain) nextBlock() *blockContext {
+	return &blockContext{height: int64(len(f.blocks)) + 1, time: f.time.Add(fakeBlockInterval)}
+}
+
+// commitBlock appends the block of ctx holding txs, and sends its events.
+func (f *FakeChain) commitBlock(ctx *blockContext, txs tmtypes.Txs, results []*rpc.ResponseDeliverTx) {
+	var last tmtypes.BlockID
+	if n := len(f.blocks); n > 0 {
+		last = f.blocks[n-1].meta.BlockID
+	}
+	f.time = ctx.time
+	f.totalTxs += int64(len(txs))
+	block := &tmtypes.Block{
+		Header: tmtypes.Header{
+			ChainID:        f.chainID,
+			Height:         ctx.height,
+			Time:           ctx.time,
+			NumTxs:         int64(len(txs)),
+			TotalTxs:       f.totalTxs,
+			LastBlockID:    last,
+			ValidatorsHash: tmhash.Sum([]byte(f.chainID)),
+		},
+		Data:       tmtypes.Data{Txs: txs},
+		LastCommit: &tmtypes.Commit{BlockID: last},
+	}
+	hash := block.Hash()
+	f.blocks = append(f.blocks, &fakeBlock{
+		block:   block,
+		meta:    &tmtypes.BlockMeta{BlockID: tmtypes.BlockID{Hash: hash}, Header: block.Header},
+		results: results,
+	})
+
+	f.publish(map[string][]string{tmtypes.EventTypeKey: {tmtypes.EventNewBlock}}, tmtypes.EventDataNewBlock{Block: block})
+	for i, t := range txs {
+		result := &rpc.ResultTx{Hash: t.Hash(), Height: ctx.height, Index: uint32(i), Tx: t, TxResult: *results[i]}
+		f.txs[string(result.Hash)] = result
+		f.txOrder = append(f.txOrder, result)
+		r := result.TxResult
+		f.publish(txEvents(result), tmtypes.EventDataTx{TxResult: tmtypes.TxResult{
+			Height: ctx.height,
+			Index:  uint32(i),
+			Tx:     t,
+			Result: abci.ResponseDeliverTx{Code: r.Code, Data: r.Data, Log: r.Log, Events: r.Events, Codespace: r.Codespace},
+		}})
+	}
+	for _, hook := range f.hooks {
+		hook(f.blocks[len(f.blocks)-1])
+	}
+}
+
+// onCommit calls hook with every block committed from now on.
+func (f *FakeChain) onCommit(hook func(*fakeBlock)) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.hooks = append(f.hooks, hook)
+}
+
+func txEvents(t *rpc.ResultTx) map[string][]string {
+	events := map[string][]string{
+		tmtypes.EventTypeKey: {tmtypes.EventTx},
+		tmtypes.TxHashKey:    {fmt.Sprintf("%X", []byte(t.Hash))},
+		tmtypes.TxHeightKey:  {strconv.FormatInt(t.Height, 10)},
+	}
+	for _, tag := range t.TxResult.Tags {
+		events[string(tag.Key)] = append(events[string(tag.Key)], string(tag.Value))
+	}
+	return events
+}
+
+func (f *FakeChain) publish(events map[string][]string, data tmtypes.TMEventData) {
+	for q, sub := range f.subs {
+		if ok, err := sub.query.Matches(events); err != nil || !ok {
+			continue
+		}
+		select {
+		case sub.out <- ctypes.ResultEvent{Query: q, Data: data, Events: events}:
+		default:
+		}
+	}
+}
+
+// checkTx decodes bz and checks the signatures of the tx against the accounts.
+func (f *FakeChain) checkTx(bz []byte) (tx.StdTx, *tx.ABCIError) {
+	var stdTx tx.StdTx
+	if err := f.cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx); err != nil {
+		return stdTx, abciError(tx.CodespaceRoot, codeTxDecode, "error decoding tx: %v", err)
+	}
+	// like the chain, which only accepts txs of a single msg
+	if len(stdTx.Msgs) != 1 {
+		return stdTx, abciError(tx.CodespaceRoot, codeInternal, "tx must have exactly one msg, got %d", len(stdTx.Msgs))
+	}
+	for _, m := range stdTx.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return stdTx, tx.NewABCIError(uint32(err.ABCICode()), err.ABCILog()).(*tx.ABCIError)
+		}
+	}
+	signers := stdTx.GetSigners()
+	if len(stdTx.Signatures) != len(signers) {
+		return stdTx, abciError(tx.CodespaceRoot, codeUnauthorized, "wrong number of signers, expected %d, got %d", len(signers), len(stdTx.Signatures))
+	}
+	for i, sig := range stdTx.Signatures {
+		acc := f.state.account(signers[i])
+		if acc == nil {
+			return stdTx, abciError(tx.CodespaceRoot, codeUnknownAddress, "account %s does not exist", signers[i])
+		}
+		pubKey := sig.PubKey
+		if pubKey == nil {
+			pubKey = acc.GetPubKey()
+		}
+		if pubKey == nil || !bytes.Equal(pubKey.Address(), signers[i]) {
+			return stdTx, abciError(tx.CodespaceRoot, codeInvalidPubKey, "the pubkey does not match the signer %s", signers[i])
+		}
+		if sig.AccountNumber != acc.GetAccountNumber() {
+			return stdTx, abciError(tx.CodespaceRoot, codeUnauthorized, "invalid account number, expected %d, got %d", acc.GetAccountNumber(), sig.AccountNumber)
+		}
+		if sig.Sequence != acc.GetSequence() {
+			return stdTx, abciError(tx.CodespaceRoot, codeInvalidSequence, "invalid sequence, expected %d, got %d", acc.GetSequence(), sig.Sequence)
+		}
+		signBytes := tx.StdSignBytes(f.chainID, sig.AccountNumber, sig.Sequence, stdTx.Msgs, stdTx.Memo, stdTx.Source, stdTx.Data)
+		if !pubKey.VerifyBytes(signBytes, sig.Signature) {
+			return stdTx, abciError(tx.CodespaceRoot, codeUnauthorized, "signature verification failed")
+		}
+	}
+	return stdTx, nil
+}
+
+// deliverTx commits stdTx, which passed checkTx, in a new block. The sequences of the
+// signers are increased even if a msg fails, the changes of the msgs are not.
+func (f *FakeChain) deliverTx(bz []byte, stdTx tx.StdTx) (*rpc.ResponseDeliverTx, int64) {
+	for i, signer := range stdTx.GetSigners() {
+		acc := f.state.account(signer)
+		if acc.GetPubKey() == nil {
+			_ = acc.SetPubKey(stdTx.Signatures[i].PubKey)
+		}
+		_ = acc.SetSequence(acc.GetSequence() + 1)
+	}
+	t := tmtypes.Tx(bz)
+	ctx := f.nextBlock()
+	ctx.txHash = t.Hash()
+	result := &rpc.ResponseDeliverTx{}
+	state := f.state.clone()
+	for _, m := range stdTx.Msgs {
+		if err := state.apply(ctx, m); err != nil {
+			result.Code, result.Log = err.Code, err.Log
+			break
+		}
+		result.Tags = append(result.Tags, cmn.KVPair{Key: []byte("action"), Value: []byte(m.Type())})
+	}
+	if result.Code == 0 {
+		f.state = state
+		result.Data = ctx.data
+		result.Events = []abci.Event{{Attributes: result.Tags}}
+	} else {
+		result.Tags = nil
+	}
+	f.commitBlock(ctx, tmtypes.Txs{t}, []*rpc.ResponseDeliverTx{result})
+	return result, ctx.height
+}
+
+func (f *FakeChain) height() int64 {
+	return int64(len(f.blocks))
+}
+
+// block returns the block at height, the latest one if nil.
+func (f *FakeChain) block(height *int64) (*fakeBlock, error) {
+	h := f.height()
+	if height != nil {
+		h = *height
+	}
+	if h <= 0 || h > f.height() {
+		return nil, fmt.Errorf("height %d must be greater than 0 and less than or equal to the current blockchain height %d", h, f.height())
+	}
+	return f.blocks[h-1], nil
+}
+
+func (f *FakeChain) OnStart() error {
+	return nil
+}
+
+// ABCIClient
+
+func (f *FakeChain) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return &ctypes.ResultABCIInfo{Response: abci.ResponseInfo{LastBlockHeight: f.height()}}, nil
+}
+
+func (f *FakeChain) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return f.ABCIQueryWithOptions(path, data, client.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions answers the account and token queries of the chain, at the
+// latest height whatever opts.
+func (f *FakeChain) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	resp := abci.ResponseQuery{Height: f.height()}
+	value, err := f.query(path)
+	if err != nil {
+		resp.Code, resp.Log = err.Code, err.Log
+	}
+	resp.Value = value
+	return &ctypes.ResultABCIQuery{Response: resp}, nil
+}
+
+func (f *FakeChain) query(path string) ([]byte, *tx.ABCIError) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "account":
+		addr, err := types.AccAddressFromBech32(parts[1])
+		if err != nil {
+			return nil, abciError(tx.CodespaceRoot, codeUnknownRequest, "invalid address %s", parts[1])
+		}
+		acc := f.state.account(addr)
+		if acc == nil {
+			return nil, nil
+		}
+		bz, _ := f.cdc.MarshalBinaryBare(acc)
+		return bz, nil
+	case len(parts) == 3 && parts[0] == "tokens" && parts[1] == "info":
+		token, err := f.state.token(parts[2])
+		if err != nil {
+			return nil, err
+		}
+		bz, _ := f.cdc.MarshalBinaryLengthPrefixed(*token)
+		return bz, nil
+	case len(parts) == 4 && parts[0] == "tokens" && parts[1] == "list":
+		offset, err1 := strconv.Atoi(parts[2])
+		limit, err2 := strconv.Atoi(parts[3])
+		if err1 != nil || err2 != nil {
+			return nil, abciError(tx.CodespaceRoot, codeUnknownRequest, "invalid offset or limit")
+		}
+		bz, _ := f.cdc.MarshalBinaryLengthPrefixed(f.tokens(offset, limit))
+		return bz, nil
+	}
+	return nil, abciError(tx.CodespaceRoot, codeUnknownRequest, "unknown query path %s", path)
+}
+
+func (f *FakeChain) tokens(offset, limit int) []types.Token {
+	tokens := make([]types.Token, 0)
+	for i := offset; i < len(f.state.tokenOrder) && len(tokens) < limit; i++ {
+		tokens = append(tokens, *f.state.tokens[f.state.tokenOrder[i]])
+	}
+	return tokens
+}
+
+// BroadcastTxCommit checks and commits t at once.
+func (f *FakeChain) BroadcastTxCommit(t tmtypes.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	res := &rpc.ResultBroadcastTxCommit{Hash: t.Hash()}
+	stdTx, err := f.checkTx(t)
+	if err != nil {
+		res.CheckTx = rpc.ResponseCheckTx{Code: err.Code, Log: err.Log}
+		return res, nil
+	}
+	deliver, height := f.deliverTx(t, stdTx)
+	res.DeliverTx, res.Height = *deliver, height
+	return res, nil
+}
+
+// BroadcastTxAsync commits t at once if it passes the checks, which are not reported
+// as with a node.
+func (f *FakeChain) BroadcastTxAsync(t tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if _, err := f.BroadcastTxSync(t); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBroadcastTx{Hash: t.Hash()}, nil
+}
+
+// BroadcastTxSync commits t at once if it passes the checks, and returns the
+// outcome of the checks.
+func (f *FakeChain) BroadcastTxSync(t tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	res := &ctypes.ResultBroadcastTx{Hash: t.Hash()}
+	stdTx, err := f.checkTx(t)
+	if err != nil {
+		res.Code, res.Log = err.Code, err.Log
+		return res, nil
+	}
+	f.deliverTx(t, stdTx)
+	return res, nil
+}
+
+// SignClient
+
+func (f *FakeChain) Block(height *int64) (*ctypes.ResultBlock, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	b, err := f.block(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultBlock{BlockMeta: b.meta, Block: b.block}, nil
+}
+
+func (f *FakeChain) BlockResults(height *int64) (*rpc.ResultBlockResults, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	b, err := f.block(height)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ResultBlockResults{
+		Height:  b.block.Height,
+		Results: &rpc.ABCIResponses{DeliverTx: b.results, EndBlock: &rpc.ResponseEndBlock{}, BeginBlock: &rpc.ResponseBeginBlock{}},
+	}, nil
+}
+
+// Commit returns the header at height, with a commit holding no signature.
+func (f *FakeChain) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	b, err := f.block(height)
+	if err != nil {
+		return nil, err
+	}
+	header := b.block.Header
+	commit := &tmtypes.Commit{BlockID: b.meta.BlockID}
+	return &ctypes.ResultCommit{SignedHeader: tmtypes.SignedHeader{Header: &header, Commit: commit}, CanonicalCommit: true}, nil
+}
+
+// Validators returns no validator, the chain has none.
+func (f *FakeChain) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	b, err := f.block(height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultValidators{BlockHeight: b.block.Height}, nil
+}
+
+func (f *FakeChain) Tx(hash []byte, prove bool) (*rpc.ResultTx, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	t, ok := f.txs[string(hash)]
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	res := *t
+	return &res, nil
+}
+
+// TxSearch finds the txs matching q, on tx.hash, tx.height and the tags of the msgs.
+func (f *FakeChain) TxSearch(q string, prove bool, page, perPage int) (*rpc.ResultTxSearch, error) {
+	parsed, err := query.New(q)
+	if err != nil {
+		return nil, err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var found []*rpc.ResultTx
+	for _, t := range f.txOrder {
+		if ok, err := parsed.Matches(txEvents(t)); err != nil {
+			return nil, err
+		} else if ok {
+			res := *t
+			found = append(found, &res)
+		}
+	}
+	if perPage <= 0 {
+		perPage = defaultTxSearchPerPage
+	} else if perPage > maxTxSearchPerPage {
+		perPage = maxTxSearchPerPage
+	}
+	if page <= 0 {
+		page = 1
+	}
+	pages := (len(found) + perPage - 1) / perPage
+	if page > pages && len(found) > 0 {
+		return nil, fmt.Errorf("page should be within [1, %d] range, given %d", pages, page)
+	}
+	start := (page - 1) * perPage
+	end := start + perPage
+	if end > len(found) {
+		end = len(found)
+	}
+	return &rpc.ResultTxSearch{Txs: found[start:end], TotalCount: len(found)}, nil
+}
+
+// HistoryClient
+
+func (f *FakeChain) Genesis() (*ctypes.ResultGenesis, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return &ctypes.ResultGenesis{Genesis: &tmtypes.GenesisDoc{
+		ChainID:     f.chainID,
+		GenesisTime: f.blocks[0].block.Time.Add(-fakeBlockInterval),
+	}}, nil
+}
+
+func (f *FakeChain) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	if minHeight < 0 || maxHeight < 0 {
+		return nil, errors.New("heights must be non negative")
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	latest := f.height()
+	if maxHeight == 0 || maxHeight > latest {
+		maxHeight = latest
+	}
+	if minHeight < 1 {
+		minHeight = 1
+	}
+	if minHeight < maxHeight-maxBlockchainInfo+1 {
+		minHeight = maxHeight - maxBlockchainInfo + 1
+	}
+	if minHeight > maxHeight {
+		return nil, fmt.Errorf("min height %d can't be greater than max height %d", minHeight, maxHeight)
+	}
+	var metas []*tmtypes.BlockMeta
+	for h := maxHeight; h >= minHeight; h-- {
+		metas = append(metas, f.blocks[h-1].meta)
+	}
+	return &ctypes.ResultBlockchainInfo{LastHeight: latest, BlockMetas: metas}, nil
+}
+
+// StatusClient
+
+func (f *FakeChain) Status() (*ctypes.ResultStatus, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	latest := f.blocks[len(f.blocks)-1]
+	return &ctypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{Network: f.chainID, Moniker: "fake"},
+		SyncInfo: ctypes.SyncInfo{
+			LatestBlockHash:   latest.meta.BlockID.Hash,
+			LatestBlockHeight: latest.block.Height,
+			LatestBlockTime:   latest.block.Time,
+			IndexHeight:       latest.block.Height,
+		},
+	}, nil
+}
+
+// EventsClient
+
+// Subscribe sends the NewBlock and Tx events matching q to the returned channel, of
+// outCapacity, 1 by default. The events are dropped while it is full.
+func (f *FakeChain) Subscribe(q string, outCapacity ...int) (chan ctypes.ResultEvent, error) {
+	parsed, err := query.New(q)
+	if err != nil {
+		return nil, err
+	}
+	capacity := 1
+	if len(outCapacity) > 0 {
+		capacity = outCapacity[0]
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if _, ok := f.subs[q]; ok {
+		return nil, errors.New("already subscribe")
+	}
+	out := make(chan ctypes.ResultEvent, capacity)
+	f.subs[q] = &fakeSubscription{query: parsed, out: out}
+	return out, nil
+}
+
+func (f *FakeChain) Unsubscribe(q string) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if _, ok := f.subs[q]; !ok {
+		return errors.New("subscription not found")
+	}
+	delete(f.subs, q)
+	return nil
+}
+
+func (f *FakeChain) UnsubscribeAll() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.subs = make(map[string]*fakeSubscription)
+	return nil
+}
+
+// OpsClient
+
+func (f *FakeChain) IsActive() bool {
+	return true
+}
+
+func (f *FakeChain) GetStakeValidators() ([]types.Validator, error) {
+	return []types.Validator{}, nil
+}
+
+func (f *FakeChain) GetDelegatorUnbondingDelegations(delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	return []types.UnbondingDelegation{}, nil
+}
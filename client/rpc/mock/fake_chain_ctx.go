@@ -0,0 +1,466 @@
+package mock
+
+import (
+	"context"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// The Ctx methods of FakeChain return the error of ctx once it is done, and
+// otherwise behave as the methods without a context.
+
+func (f *FakeChain) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Status()
+}
+
+func (f *FakeChain) BlockchainInfoCtx(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.BlockchainInfo(minHeight, maxHeight)
+}
+
+func (f *FakeChain) GenesisCtx(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Genesis()
+}
+
+func (f *FakeChain) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.ABCIInfo()
+}
+
+func (f *FakeChain) ABCIQueryCtx(ctx context.Context, path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.ABCIQuery(path, data)
+}
+
+func (f *FakeChain) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.ABCIQueryWithOptions(path, data, opts)
+}
+
+func (f *FakeChain) BroadcastTxCommitCtx(ctx context.Context, tx tmtypes.Tx) (*rpc.ResultBroadcastTxCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.BroadcastTxCommit(tx)
+}
+
+func (f *FakeChain) BroadcastTxAsyncCtx(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.BroadcastTxAsync(tx)
+}
+
+func (f *FakeChain) BroadcastTxSyncCtx(ctx context.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.BroadcastTxSync(tx)
+}
+
+func (f *FakeChain) BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Block(height)
+}
+
+func (f *FakeChain) BlockResultsCtx(ctx context.Context, height *int64) (*rpc.ResultBlockResults, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.BlockResults(height)
+}
+
+func (f *FakeChain) CommitCtx(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Commit(height)
+}
+
+func (f *FakeChain) ValidatorsCtx(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Validators(height)
+}
+
+func (f *FakeChain) TxCtx(ctx context.Context, hash []byte, prove bool) (*rpc.ResultTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Tx(hash, prove)
+}
+
+func (f *FakeChain) TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*rpc.ResultTxSearch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.TxSearch(query, prove, page, perPage)
+}
+
+func (f *FakeChain) BroadcastCtx(ctx context.Context, m msg.Msg, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Broadcast(m, syncType, options...)
+}
+
+func (f *FakeChain) TxInfoSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) ([]rpc.Info, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.TxInfoSearch(query, prove, page, perPage)
+}
+
+func (f *FakeChain) ListAllTokensCtx(ctx context.Context, offset int, limit int) ([]types.Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.ListAllTokens(offset, limit)
+}
+
+func (f *FakeChain) GetTokenInfoCtx(ctx context.Context, symbol string) (*types.Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetTokenInfo(symbol)
+}
+
+func (f *FakeChain) GetAccountCtx(ctx context.Context, addr types.AccAddress) (types.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetAccount(addr)
+}
+
+func (f *FakeChain) GetCommitAccountCtx(ctx context.Context, addr types.AccAddress) (types.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetCommitAccount(addr)
+}
+
+func (f *FakeChain) GetBalancesCtx(ctx context.Context, addr types.AccAddress) ([]types.TokenBalance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetBalances(addr)
+}
+
+func (f *FakeChain) GetBalanceCtx(ctx context.Context, addr types.AccAddress, symbol string) (*types.TokenBalance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetBalance(addr, symbol)
+}
+
+func (f *FakeChain) GetFeeCtx(ctx context.Context) ([]types.FeeParam, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetFee()
+}
+
+func (f *FakeChain) GetProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64) ([]types.Proposal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetProposals(status, numLatest)
+}
+
+func (f *FakeChain) GetSideChainProposalsCtx(ctx context.Context, status types.ProposalStatus, numLatest int64, sideChainId string) ([]types.Proposal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainProposals(status, numLatest, sideChainId)
+}
+
+func (f *FakeChain) GetSideChainProposalCtx(ctx context.Context, proposalId int64, sideChainId string) (types.Proposal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainProposal(proposalId, sideChainId)
+}
+
+func (f *FakeChain) GetProposalCtx(ctx context.Context, proposalId int64) (types.Proposal, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetProposal(proposalId)
+}
+
+func (f *FakeChain) GetTimelocksCtx(ctx context.Context, addr types.AccAddress) ([]types.TimeLockRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetTimelocks(addr)
+}
+
+func (f *FakeChain) GetTimelockCtx(ctx context.Context, addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetTimelock(addr, recordID)
+}
+
+func (f *FakeChain) GetSwapByIDCtx(ctx context.Context, swapID types.SwapBytes) (types.AtomicSwap, error) {
+	if err := ctx.Err(); err != nil {
+		return types.AtomicSwap{}, err
+	}
+	return f.GetSwapByID(swapID)
+}
+
+func (f *FakeChain) GetSwapByCreatorCtx(ctx context.Context, creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSwapByCreator(creatorAddr, offset, limit)
+}
+
+func (f *FakeChain) GetSwapByRecipientCtx(ctx context.Context, recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSwapByRecipient(recipientAddr, offset, limit)
+}
+
+func (f *FakeChain) GetSideChainParamsCtx(ctx context.Context, sideChainId string) ([]msg.SCParam, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainParams(sideChainId)
+}
+
+func (f *FakeChain) ListAllMiniTokensCtx(ctx context.Context, offset int, limit int) ([]types.MiniToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.ListAllMiniTokens(offset, limit)
+}
+
+func (f *FakeChain) GetMiniTokenInfoCtx(ctx context.Context, symbol string) (*types.MiniToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetMiniTokenInfo(symbol)
+}
+
+func (f *FakeChain) GetProphecyCtx(ctx context.Context, chainId types.IbcChainID, sequence int64) (*msg.Prophecy, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetProphecy(chainId, sequence)
+}
+
+func (f *FakeChain) GetCurrentOracleSequenceCtx(ctx context.Context, chainId types.IbcChainID) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return f.GetCurrentOracleSequence(chainId)
+}
+
+func (f *FakeChain) GetStakeValidatorsCtx(ctx context.Context) ([]types.Validator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetStakeValidators()
+}
+
+func (f *FakeChain) GetDelegatorUnbondingDelegationsCtx(ctx context.Context, delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetDelegatorUnbondingDelegations(delegatorAddr)
+}
+
+func (f *FakeChain) QueryValidatorCtx(ctx context.Context, valAddr types.ValAddress) (*types.Validator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryValidator(valAddr)
+}
+
+func (f *FakeChain) QueryTopValidatorsCtx(ctx context.Context, top int) ([]types.Validator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryTopValidators(top)
+}
+
+func (f *FakeChain) QueryDelegationCtx(ctx context.Context, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryDelegation(delAddr, valAddr)
+}
+
+func (f *FakeChain) QueryDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.DelegationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryDelegations(delAddr)
+}
+
+func (f *FakeChain) QueryRedelegationCtx(ctx context.Context, delAddr types.AccAddress, valSrcAddr types.ValAddress, valDstAddr types.ValAddress) (*types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryRedelegation(delAddr, valSrcAddr, valDstAddr)
+}
+
+func (f *FakeChain) QueryRedelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryRedelegations(delAddr)
+}
+
+func (f *FakeChain) QueryUnbondingDelegationCtx(ctx context.Context, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryUnbondingDelegation(valAddr, delAddr)
+}
+
+func (f *FakeChain) QueryUnbondingDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QueryUnbondingDelegations(delAddr)
+}
+
+func (f *FakeChain) GetUnBondingDelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetUnBondingDelegationsByValidator(valAddr)
+}
+
+func (f *FakeChain) GetRedelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetRedelegationsByValidator(valAddr)
+}
+
+func (f *FakeChain) GetPoolCtx(ctx context.Context) (*types.Pool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetPool()
+}
+
+func (f *FakeChain) GetAllValidatorsCountCtx(ctx context.Context, jailInvolved bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return f.GetAllValidatorsCount(jailInvolved)
+}
+
+func (f *FakeChain) QuerySideChainValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) (*types.Validator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainValidator(sideChainId, valAddr)
+}
+
+func (f *FakeChain) QuerySideChainTopValidatorsCtx(ctx context.Context, sideChainId string, top int) ([]types.Validator, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainTopValidators(sideChainId, top)
+}
+
+func (f *FakeChain) QuerySideChainDelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainDelegation(sideChainId, delAddr, valAddr)
+}
+
+func (f *FakeChain) QuerySideChainDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.DelegationResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainDelegations(sideChainId, delAddr)
+}
+
+func (f *FakeChain) QuerySideChainRedelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valSrcAddr types.ValAddress, valDstAddr types.ValAddress) (*types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainRedelegation(sideChainId, delAddr, valSrcAddr, valDstAddr)
+}
+
+func (f *FakeChain) QuerySideChainRedelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainRedelegations(sideChainId, delAddr)
+}
+
+func (f *FakeChain) QuerySideChainUnbondingDelegationCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainUnbondingDelegation(sideChainId, valAddr, delAddr)
+}
+
+func (f *FakeChain) QuerySideChainUnbondingDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.QuerySideChainUnbondingDelegations(sideChainId, delAddr)
+}
+
+func (f *FakeChain) GetSideChainUnBondingDelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainUnBondingDelegationsByValidator(sideChainId, valAddr)
+}
+
+func (f *FakeChain) GetSideChainRedelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainRedelegationsByValidator(sideChainId, valAddr)
+}
+
+func (f *FakeChain) GetSideChainPoolCtx(ctx context.Context, sideChainId string) (*types.Pool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.GetSideChainPool(sideChainId)
+}
+
+func (f *FakeChain) GetSideChainAllValidatorsCountCtx(ctx context.Context, sideChainId string, jailInvolved bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return f.GetSideChainAllValidatorsCount(sideChainId, jailInvolved)
+}
@@ -0,0 +1,295 @@
+package mock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/keys"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func newTestKey(t *testing.T) keys.KeyManager {
+	k, err := keys.NewKeyManager()
+	assert.NoError(t, err)
+	return k
+}
+
+func bnb(amount int64) types.Coins {
+	return types.Coins{{Denom: "BNB", Amount: amount}}
+}
+
+func TestFakeChainTransfer(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice, bob := newTestKey(t), newTestKey(t)
+	chain.Fund(alice.GetAddr(), bnb(1000))
+	chain.SetKeyManager(alice)
+
+	blocks, err := chain.Subscribe("tm.event='NewBlock'", 10)
+	assert.NoError(t, err)
+	txs, err := chain.Subscribe("tm.event='Tx'", 10)
+	assert.NoError(t, err)
+
+	res, err := chain.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(300)}}, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	balance, err := chain.GetBalance(alice.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(700), balance.Free.ToInt64())
+	balance, err = chain.GetBalance(bob.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), balance.Free.ToInt64())
+	acc, err := chain.GetAccount(alice.GetAddr())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acc.GetSequence())
+
+	// the tx is in a block of its own, found by hash and by height
+	block := (<-blocks).Data.(tmtypes.EventDataNewBlock).Block
+	assert.Equal(t, int64(2), block.Height)
+	event := (<-txs).Data.(tmtypes.EventDataTx)
+	assert.Equal(t, block.Height, event.Height)
+	found, err := chain.Tx(res.Hash, false)
+	assert.NoError(t, err)
+	assert.Equal(t, block.Height, found.Height)
+	infos, err := chain.TxInfoSearch(fmt.Sprintf("tx.height=%d", block.Height), false, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, infos, 1)
+	status, err := chain.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, block.Height, status.SyncInfo.LatestBlockHeight)
+	previous := block.Height - 1
+	genesis, err := chain.Block(&previous)
+	assert.NoError(t, err)
+	assert.Equal(t, genesis.BlockMeta.BlockID, block.LastBlockID)
+
+	// failed checks are reported by the sync broadcasts, and nothing is committed
+	signed := func(chainID string, sequence int64) []byte {
+		bz, err := alice.Sign(tx.StdSignMsg{
+			ChainID:  chainID,
+			Sequence: sequence,
+			Msgs:     []msg.Msg{msg.CreateSendMsg(alice.GetAddr(), bnb(1), []msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(1)}})},
+		})
+		assert.NoError(t, err)
+		return bz
+	}
+	check := func(bz []byte, kind error) {
+		res, err := chain.BroadcastTxSync(bz)
+		assert.NoError(t, err)
+		assert.True(t, errors.Is(tx.NewABCIError(res.Code, res.Log), kind), res.Log)
+	}
+	check(signed("test-chain", 0), tx.ErrInvalidSequence)
+	check(signed("other-chain", 1), tx.ErrUnauthorized)
+	check([]byte("garbage"), tx.ErrTxDecode)
+	check(signed("test-chain", 1)[:20], tx.ErrTxDecode)
+
+	// a failed msg is committed, only the sequence changes
+	res, err = chain.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(5000)}}, rpc.Commit)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(tx.NewABCIError(res.Code, res.Log), tx.ErrInsufficientFunds), res.Log)
+	acc, err = chain.GetAccount(alice.GetAddr())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), acc.GetSequence())
+	assert.Equal(t, int64(700), acc.GetCoins().AmountOf("BNB"))
+
+	res, err = chain.BroadcastTxSync(signed("test-chain", 2))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	balance, err = chain.GetBalance(bob.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(301), balance.Free.ToInt64())
+}
+
+func TestFakeChainTokens(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	owner, other := newTestKey(t), newTestKey(t)
+	chain.Fund(owner.GetAddr(), bnb(1000))
+	chain.Fund(other.GetAddr(), bnb(1000))
+	chain.SetKeyManager(owner)
+
+	res, err := chain.Broadcast(msg.NewTokenIssueMsg(owner.GetAddr(), "Test Token", "TST", 1000, true), rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	var issued types.Token
+	assert.NoError(t, json.Unmarshal(res.Data, &issued))
+	symbol := issued.Symbol
+	assert.Equal(t, fmt.Sprintf("TST-%X", []byte(res.Hash[:2]))[:7], symbol)
+	token, err := chain.GetTokenInfo(symbol)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), token.TotalSupply.ToInt64())
+
+	for _, m := range []msg.Msg{
+		msg.NewMintMsg(owner.GetAddr(), symbol, 500),
+		msg.NewTokenBurnMsg(owner.GetAddr(), symbol, 200),
+		msg.NewFreezeMsg(owner.GetAddr(), symbol, 300),
+		msg.NewUnfreezeMsg(owner.GetAddr(), symbol, 100),
+	} {
+		res, err = chain.Broadcast(m, rpc.Commit)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(0), res.Code, res.Log)
+	}
+	balance, err := chain.GetBalance(owner.GetAddr(), symbol)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1100), balance.Free.ToInt64())
+	assert.Equal(t, int64(200), balance.Frozen.ToInt64())
+	token, err = chain.GetTokenInfo(symbol)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1300), token.TotalSupply.ToInt64())
+
+	// only the owner can burn
+	chain.SetKeyManager(other)
+	res, err = chain.Broadcast(msg.NewTokenBurnMsg(other.GetAddr(), symbol, 1), rpc.Commit)
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(tx.NewABCIError(res.Code, res.Log), tx.ErrUnauthorized), res.Log)
+	_, err = chain.GetBalance(owner.GetAddr(), "NONE-000")
+	assert.True(t, errors.Is(err, tx.ErrUnknownToken))
+
+	tokens, err := chain.ListAllTokens(0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+}
+
+func TestFakeChainTimelock(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	key := newTestKey(t)
+	chain.Fund(key.GetAddr(), bnb(1000))
+	chain.SetKeyManager(key)
+
+	status, err := chain.Status()
+	assert.NoError(t, err)
+	lockTime := status.SyncInfo.LatestBlockTime.Add(time.Hour).Unix()
+	res, err := chain.Broadcast(msg.NewTimeLockMsg(key.GetAddr(), "locked", bnb(400), lockTime), rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	assert.Equal(t, "1", string(res.Data))
+	record, err := chain.GetTimelock(key.GetAddr(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, bnb(400), record.Amount)
+	balance, err := chain.GetBalance(key.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(600), balance.Free.ToInt64())
+
+	res, err = chain.Broadcast(msg.NewTimeUnlockMsg(key.GetAddr(), 1), rpc.Commit)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uint32(0), res.Code)
+
+	chain.AdvanceTime(time.Hour)
+	res, err = chain.Broadcast(msg.NewTimeUnlockMsg(key.GetAddr(), 1), rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	record, err = chain.GetTimelock(key.GetAddr(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	balance, err = chain.GetBalance(key.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), balance.Free.ToInt64())
+}
+
+func TestFakeChainHTLT(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice, bob := newTestKey(t), newTestKey(t)
+	chain.AddToken(types.Token{Name: "Other", Symbol: "OTH-000", OrigSymbol: "OTH", TotalSupply: 1000, Owner: bob.GetAddr()})
+	chain.Fund(alice.GetAddr(), bnb(1000))
+	chain.Fund(bob.GetAddr(), bnb(1000))
+
+	randomNumber := make([]byte, 32)
+	_, _ = rand.Read(randomNumber)
+	timestamp := time.Now().Unix()
+	randomNumberHash := msg.CalculateRandomHash(randomNumber, timestamp)
+	other := types.Coins{{Denom: "OTH-000", Amount: 100}}
+
+	chain.SetKeyManager(alice)
+	res, err := chain.HTLT(bob.GetAddr(), "", "", randomNumberHash, timestamp, bnb(200), "100:OTH-000", 360, false, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	swapID := msg.CalculateSwapID(randomNumberHash, alice.GetAddr(), "")
+	assert.Equal(t, swapID, []byte(res.Data))
+
+	chain.SetKeyManager(bob)
+	res, err = chain.DepositHTLT(alice.GetAddr(), swapID, other, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	res, err = chain.ClaimHTLT(swapID, randomNumber, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	swap, err := chain.GetSwapByID(swapID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Completed, swap.Status)
+	balances, err := chain.GetBalances(alice.GetAddr())
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{800, 100}, []int64{balances[0].Free.ToInt64(), balances[1].Free.ToInt64()})
+
+	// a swap not claimed is refunded once expired
+	chain.SetKeyManager(alice)
+	timestamp++
+	randomNumberHash = msg.CalculateRandomHash(randomNumber, timestamp)
+	res, err = chain.HTLT(bob.GetAddr(), "", "", randomNumberHash, timestamp, bnb(200), "100:OTH-000", 360, false, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	swapID = res.Data
+	res, err = chain.RefundHTLT(swapID, rpc.Commit)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uint32(0), res.Code)
+	chain.ProduceBlocks(360)
+	res, err = chain.RefundHTLT(swapID, rpc.Commit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.Code, res.Log)
+	balance, err := chain.GetBalance(alice.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(800), balance.Free.ToInt64())
+
+	ids, err := chain.GetSwapByCreator(alice.GetAddr().String(), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+	_, err = chain.GetSwapByCreator(bob.GetAddr().String(), 0, 10)
+	assert.Equal(t, rpc.ZeroRecordsError, err)
+}
+
+func TestFakeChainCtx(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice := newTestKey(t)
+	chain.Fund(alice.GetAddr(), bnb(1000))
+
+	balance, err := chain.GetBalanceCtx(context.Background(), alice.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), balance.Free.ToInt64())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = chain.GetBalanceCtx(ctx, alice.GetAddr(), "BNB")
+	assert.Equal(t, context.Canceled, err)
+	_, err = chain.StatusCtx(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestFakeChainSingleMsg(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice, bob := newTestKey(t), newTestKey(t)
+	chain.Fund(alice.GetAddr(), bnb(1000))
+	acc, err := chain.GetAccount(alice.GetAddr())
+	assert.NoError(t, err)
+
+	send := msg.CreateSendMsg(alice.GetAddr(), bnb(100), []msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(100)}})
+	bz, err := alice.Sign(tx.StdSignMsg{
+		ChainID:       "test-chain",
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+		Msgs:          []msg.Msg{send, send},
+	})
+	assert.NoError(t, err)
+	res, err := chain.BroadcastTxSync(bz)
+	assert.NoError(t, err)
+	assert.Equal(t, abciError(tx.CodespaceRoot, codeInternal, "").Code, res.Code, res.Log)
+	balance, err := chain.GetBalance(alice.GetAddr(), "BNB")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), balance.Free.ToInt64())
+}
@@ -0,0 +1,285 @@
+package mock
+
+import (
+	"bytes"
+	"fmt"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/keys"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// The DexClient calls modelled by FakeChain, the other ones go to the embedded
+// DexClient.
+
+func (f *FakeChain) SetKeyManager(k keys.KeyManager) {
+	f.key = k
+}
+
+// Broadcast signs m with the key manager, taking the account number and sequence
+// from the chain unless given by options, and broadcasts it.
+func (f *FakeChain) Broadcast(m msg.Msg, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	key := f.key
+	if key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	signMsg := &tx.StdSignMsg{
+		ChainID:       f.chainID,
+		AccountNumber: -1,
+		Sequence:      -1,
+		Msgs:          []msg.Msg{m},
+		Source:        tx.Source,
+	}
+	for _, op := range options {
+		signMsg = op(signMsg)
+	}
+	if signMsg.AccountNumber == -1 || signMsg.Sequence == -1 {
+		signMsg.AccountNumber, signMsg.Sequence = 0, 0
+		f.mtx.Lock()
+		if acc := f.state.account(key.GetAddr()); acc != nil {
+			signMsg.AccountNumber, signMsg.Sequence = acc.GetAccountNumber(), acc.GetSequence()
+		}
+		f.mtx.Unlock()
+	}
+	for _, m := range signMsg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	bz, err := key.Sign(*signMsg)
+	if err != nil {
+		return nil, err
+	}
+	switch syncType {
+	case rpc.Async:
+		return f.BroadcastTxAsync(bz)
+	case rpc.Sync:
+		return f.BroadcastTxSync(bz)
+	case rpc.Commit:
+		res, err := f.BroadcastTxCommit(bz)
+		if err != nil {
+			return nil, err
+		}
+		if res.CheckTx.IsErr() {
+			return &ctypes.ResultBroadcastTx{Code: res.CheckTx.Code, Log: res.CheckTx.Log, Hash: res.Hash}, nil
+		}
+		return &ctypes.ResultBroadcastTx{Code: res.DeliverTx.Code, Log: res.DeliverTx.Log, Hash: res.Hash, Data: res.DeliverTx.Data}, nil
+	}
+	return nil, fmt.Errorf("unknown synctype")
+}
+
+func (f *FakeChain) SendToken(transfers []msg.Transfer, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	fromCoins := types.Coins{}
+	for _, t := range transfers {
+		fromCoins = fromCoins.Plus(t.Coins.Sort())
+	}
+	return f.Broadcast(msg.CreateSendMsg(f.key.GetAddr(), fromCoins, transfers), syncType, options...)
+}
+
+func (f *FakeChain) HTLT(recipient types.AccAddress, recipientOtherChain, senderOtherChain string, randomNumberHash []byte, timestamp int64,
+	amount types.Coins, expectedIncome string, heightSpan int64, crossChain bool, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	htltMsg := msg.NewHTLTMsg(f.key.GetAddr(), recipient, recipientOtherChain, senderOtherChain, randomNumberHash, timestamp,
+		amount, expectedIncome, heightSpan, crossChain)
+	return f.Broadcast(htltMsg, syncType, options...)
+}
+
+func (f *FakeChain) DepositHTLT(recipient types.AccAddress, swapID []byte, amount types.Coins,
+	syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	return f.Broadcast(msg.NewDepositHTLTMsg(f.key.GetAddr(), amount, swapID), syncType, options...)
+}
+
+func (f *FakeChain) ClaimHTLT(swapID []byte, randomNumber []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	return f.Broadcast(msg.NewClaimHTLTMsg(f.key.GetAddr(), swapID, randomNumber), syncType, options...)
+}
+
+func (f *FakeChain) RefundHTLT(swapID []byte, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	return f.Broadcast(msg.NewRefundHTLTMsg(f.key.GetAddr(), swapID), syncType, options...)
+}
+
+func (f *FakeChain) TransferTokenOwnership(symbol string, newOwner types.AccAddress, syncType rpc.SyncType, options ...tx.Option) (*ctypes.ResultBroadcastTx, error) {
+	if f.key == nil {
+		return nil, rpc.KeyMissingError
+	}
+	return f.Broadcast(msg.NewTransferOwnershipMsg(f.key.GetAddr(), symbol, newOwner), syncType, options...)
+}
+
+func (f *FakeChain) TxInfoSearch(query string, prove bool, page, perPage int) ([]rpc.Info, error) {
+	if err := rpc.ValidateTxSearchQueryStr(query); err != nil {
+		return nil, err
+	}
+	res, err := f.TxSearch(query, prove, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.FormatTxResults(f.cdc, res.Txs)
+}
+
+func (f *FakeChain) ListAllTokens(offset int, limit int) ([]types.Token, error) {
+	if err := rpc.ValidateOffset(offset); err != nil {
+		return nil, err
+	}
+	if err := rpc.ValidateLimit(limit); err != nil {
+		return nil, err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.tokens(offset, limit), nil
+}
+
+func (f *FakeChain) GetTokenInfo(symbol string) (*types.Token, error) {
+	if err := rpc.ValidateSymbol(symbol); err != nil {
+		return nil, err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	token, err := f.state.token(symbol)
+	if err != nil {
+		return nil, err
+	}
+	t := *token
+	return &t, nil
+}
+
+// GetAccount returns a copy of the account of addr, nil if it does not exist.
+func (f *FakeChain) GetAccount(addr types.AccAddress) (types.Account, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	acc := f.state.account(addr)
+	if acc == nil {
+		return nil, nil
+	}
+	return acc.Clone(), nil
+}
+
+// GetCommitAccount is GetAccount, as the txs are committed once broadcast.
+func (f *FakeChain) GetCommitAccount(addr types.AccAddress) (types.Account, error) {
+	return f.GetAccount(addr)
+}
+
+func (f *FakeChain) GetBalances(addr types.AccAddress) ([]types.TokenBalance, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	acc := f.state.account(addr)
+	if acc == nil {
+		return []types.TokenBalance{}, nil
+	}
+	balances := make([]types.TokenBalance, 0, len(acc.GetCoins()))
+	for _, coin := range acc.GetCoins() {
+		balances = append(balances, balance(acc, coin.Denom))
+	}
+	return balances, nil
+}
+
+func (f *FakeChain) GetBalance(addr types.AccAddress, symbol string) (*types.TokenBalance, error) {
+	if err := rpc.ValidateSymbol(symbol); err != nil {
+		return nil, err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if _, ok := f.state.tokens[symbol]; !ok {
+		return nil, fmt.Errorf("symbol not found: %w", tx.ErrUnknownToken)
+	}
+	acc := f.state.account(addr)
+	if acc == nil {
+		return &types.TokenBalance{Symbol: symbol, Free: types.Fixed8Zero, Locked: types.Fixed8Zero, Frozen: types.Fixed8Zero}, nil
+	}
+	b := balance(acc, symbol)
+	return &b, nil
+}
+
+func balance(acc *types.AppAccount, symbol string) types.TokenBalance {
+	return types.TokenBalance{
+		Symbol: symbol,
+		Free:   types.Fixed8(acc.GetCoins().AmountOf(symbol)),
+		Locked: types.Fixed8(acc.GetLockedCoins().AmountOf(symbol)),
+		Frozen: types.Fixed8(acc.GetFrozenCoins().AmountOf(symbol)),
+	}
+}
+
+// GetFee returns no fee, the chain charges none.
+func (f *FakeChain) GetFee() ([]types.FeeParam, error) {
+	return []types.FeeParam{}, nil
+}
+
+func (f *FakeChain) GetTimelocks(addr types.AccAddress) ([]types.TimeLockRecord, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return append([]types.TimeLockRecord{}, f.state.timelocks[string(addr)]...), nil
+}
+
+// GetTimelock returns the record recordID of addr, nil if it does not exist.
+func (f *FakeChain) GetTimelock(addr types.AccAddress, recordID int64) (*types.TimeLockRecord, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	i, err := f.state.timelock(addr, recordID)
+	if err != nil {
+		return nil, nil
+	}
+	record := f.state.timelocks[string(addr)][i]
+	return &record, nil
+}
+
+func (f *FakeChain) GetSwapByID(swapID types.SwapBytes) (types.AtomicSwap, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	swap, ok := f.state.swaps[string(swapID)]
+	if !ok {
+		return types.AtomicSwap{}, rpc.ZeroRecordsError
+	}
+	return *swap, nil
+}
+
+func (f *FakeChain) GetSwapByCreator(creatorAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	return f.swapsBy(creatorAddr, offset, limit, func(swap *types.AtomicSwap) types.AccAddress { return swap.From })
+}
+
+func (f *FakeChain) GetSwapByRecipient(recipientAddr string, offset int64, limit int64) ([]types.SwapBytes, error) {
+	return f.swapsBy(recipientAddr, offset, limit, func(swap *types.AtomicSwap) types.AccAddress { return swap.To })
+}
+
+// swapsBy lists the ids of the swaps whose party is addr, in the order of creation.
+func (f *FakeChain) swapsBy(addr string, offset, limit int64, party func(*types.AtomicSwap) types.AccAddress) ([]types.SwapBytes, error) {
+	acc, err := types.AccAddressFromBech32(addr)
+	if err != nil {
+		return nil, err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var ids []types.SwapBytes
+	for _, id := range f.state.swapOrder {
+		if !bytes.Equal(party(f.state.swaps[string(id)]), acc) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if int64(len(ids)) == limit {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, rpc.ZeroRecordsError
+	}
+	return ids, nil
+}
@@ -0,0 +1,409 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// The codes of the failures, as the chain reports them.
+const (
+	codeInternal          uint16 = 1
+	codeTxDecode          uint16 = 2
+	codeInvalidSequence   uint16 = 3
+	codeUnauthorized      uint16 = 4
+	codeUnknownRequest    uint16 = 6
+	codeInvalidPubKey     uint16 = 8
+	codeUnknownAddress    uint16 = 9
+	codeInsufficientCoins uint16 = 10
+	codeInvalidCoins      uint16 = 11
+
+	codeInvalidLockTime    uint16 = 2
+	codeInvalidRelock      uint16 = 3
+	codeTimeLockNotFound   uint16 = 5
+	codeCanNotUnlock       uint16 = 7
+	codeInvalidTimestamp   uint16 = 13
+	codeDuplicatedSwapID   uint16 = 6
+	codeClaimExpiredSwap   uint16 = 7
+	codeRefundUnexpired    uint16 = 8
+	codeMismatchedRandom   uint16 = 9
+	codeSwapNotFound       uint16 = 10
+	codeUnexpectedStatus   uint16 = 12
+	codeInvalidSingleChain uint16 = 14
+	codeNotDepositedSwap   uint16 = 16
+)
+
+const (
+	// timelockMinTime is how long after the block time coins can be locked until.
+	timelockMinTime = 60 * time.Second
+	// the timestamp of a HTLT is at most 30 minutes before the block time, and at
+	// most 15 minutes after
+	swapPastTimestamp   = 1800
+	swapFutureTimestamp = 900
+)
+
+func abciError(codespace, code uint16, format string, args ...interface{}) *tx.ABCIError {
+	return tx.NewABCIError(uint32(codespace)<<16|uint32(code), fmt.Sprintf(format, args...)).(*tx.ABCIError)
+}
+
+// blockContext is the block a tx is delivered in, data is the result of its msgs.
+type blockContext struct {
+	height int64
+	time   time.Time
+	txHash []byte
+	data   []byte
+}
+
+// fakeState is the state of a FakeChain. A tx is applied to a copy of it, which
+// replaces it once all the msgs succeeded.
+type fakeState struct {
+	accounts   map[string]*types.AppAccount
+	nextNumber int64
+	tokens     map[string]*types.Token
+	tokenOrder []string
+	timelocks  map[string][]types.TimeLockRecord
+	swaps      map[string]*types.AtomicSwap
+	swapOrder  []types.SwapBytes
+}
+
+func newFakeState() *fakeState {
+	return &fakeState{
+		accounts:  make(map[string]*types.AppAccount),
+		tokens:    make(map[string]*types.Token),
+		timelocks: make(map[string][]types.TimeLockRecord),
+		swaps:     make(map[string]*types.AtomicSwap),
+	}
+}
+
+func (s *fakeState) clone() *fakeState {
+	c := newFakeState()
+	c.nextNumber = s.nextNumber
+	for k, acc := range s.accounts {
+		c.accounts[k] = acc.Clone().(*types.AppAccount)
+	}
+	for k, token := range s.tokens {
+		t := *token
+		c.tokens[k] = &t
+	}
+	c.tokenOrder = append(c.tokenOrder, s.tokenOrder...)
+	for k, records := range s.timelocks {
+		c.timelocks[k] = append([]types.TimeLockRecord(nil), records...)
+	}
+	for k, swap := range s.swaps {
+		sw := *swap
+		c.swaps[k] = &sw
+	}
+	c.swapOrder = append(c.swapOrder, s.swapOrder...)
+	return c
+}
+
+func (s *fakeState) account(addr types.AccAddress) *types.AppAccount {
+	return s.accounts[string(addr)]
+}
+
+// getOrCreate returns the account of addr, created with the next account number
+// when it receives its first coins.
+func (s *fakeState) getOrCreate(addr types.AccAddress) *types.AppAccount {
+	acc := s.account(addr)
+	if acc == nil {
+		acc = &types.AppAccount{BaseAccount: types.BaseAccount{Address: addr, AccountNumber: s.nextNumber}}
+		s.nextNumber++
+		s.accounts[string(addr)] = acc
+	}
+	return acc
+}
+
+func (s *fakeState) addCoins(addr types.AccAddress, coins types.Coins) {
+	acc := s.getOrCreate(addr)
+	acc.SetCoins(acc.GetCoins().Plus(coins.Sort()))
+}
+
+func (s *fakeState) subtractCoins(addr types.AccAddress, coins types.Coins) *tx.ABCIError {
+	acc := s.account(addr)
+	if acc == nil {
+		return abciError(tx.CodespaceRoot, codeUnknownAddress, "account %s does not exist", addr)
+	}
+	left := acc.GetCoins().Minus(coins.Sort())
+	if !left.IsNotNegative() {
+		return abciError(tx.CodespaceRoot, codeInsufficientCoins, "%s is less than %s", acc.GetCoins(), coins)
+	}
+	acc.SetCoins(left)
+	return nil
+}
+
+func (s *fakeState) addToken(token types.Token) {
+	if _, ok := s.tokens[token.Symbol]; !ok {
+		s.tokenOrder = append(s.tokenOrder, token.Symbol)
+	}
+	s.tokens[token.Symbol] = &token
+}
+
+func (s *fakeState) token(symbol string) (*types.Token, *tx.ABCIError) {
+	token, ok := s.tokens[symbol]
+	if !ok {
+		return nil, abciError(tx.CodespaceRoot, codeUnknownRequest, "token %s does not exist", symbol)
+	}
+	return token, nil
+}
+
+// ownedToken returns the token of symbol if from owns it.
+func (s *fakeState) ownedToken(symbol string, from types.AccAddress) (*types.Token, *tx.ABCIError) {
+	token, err := s.token(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if !token.IsOwner(from) {
+		return nil, abciError(tx.CodespaceRoot, codeUnauthorized, "only the owner of the token can do this")
+	}
+	return token, nil
+}
+
+func (s *fakeState) timelock(addr types.AccAddress, id int64) (int, *tx.ABCIError) {
+	for i, record := range s.timelocks[string(addr)] {
+		if record.Id == id {
+			return i, nil
+		}
+	}
+	return 0, abciError(tx.CodespaceTimeLock, codeTimeLockNotFound, "time lock record does not exist, addr=%s, id=%d", addr, id)
+}
+
+func (s *fakeState) swap(id types.SwapBytes) (*types.AtomicSwap, *tx.ABCIError) {
+	swap, ok := s.swaps[string(id)]
+	if !ok {
+		return nil, abciError(tx.CodespaceAtomicSwap, codeSwapNotFound, "no matched swap with swapID %X", []byte(id))
+	}
+	return swap, nil
+}
+
+// apply applies m, the msg of a tx delivered in ctx.
+func (s *fakeState) apply(ctx *blockContext, m msg.Msg) *tx.ABCIError {
+	switch m := m.(type) {
+	case msg.SendMsg:
+		for _, in := range m.Inputs {
+			if err := s.subtractCoins(in.Address, in.Coins); err != nil {
+				return err
+			}
+		}
+		for _, out := range m.Outputs {
+			s.addCoins(out.Address, out.Coins)
+		}
+	case msg.TokenIssueMsg:
+		symbol := fmt.Sprintf("%s-%X", strings.ToUpper(m.Symbol), ctx.txHash)[:len(m.Symbol)+1+3]
+		if _, ok := s.tokens[symbol]; ok {
+			return abciError(tx.CodespaceRoot, codeInvalidCoins, "token %s already exists", symbol)
+		}
+		token := types.Token{
+			Name:        m.Name,
+			Symbol:      symbol,
+			OrigSymbol:  strings.ToUpper(m.Symbol),
+			TotalSupply: types.Fixed8(m.TotalSupply),
+			Owner:       m.From,
+			Mintable:    m.Mintable,
+		}
+		s.addToken(token)
+		s.addCoins(m.From, types.Coins{types.Coin{Denom: symbol, Amount: m.TotalSupply}})
+		serialized, _ := json.Marshal(token)
+		ctx.data = append(ctx.data, serialized...)
+	case msg.MintMsg:
+		token, err := s.ownedToken(m.Symbol, m.From)
+		if err != nil {
+			return err
+		}
+		if !token.Mintable {
+			return abciError(tx.CodespaceRoot, codeInvalidCoins, "token %s cannot be minted", m.Symbol)
+		}
+		token.TotalSupply += types.Fixed8(m.Amount)
+		s.addCoins(m.From, types.Coins{types.Coin{Denom: m.Symbol, Amount: m.Amount}})
+		ctx.data = append(ctx.data, fmt.Sprintf("%d", token.TotalSupply.ToInt64())...)
+	case msg.TokenBurnMsg:
+		token, err := s.ownedToken(m.Symbol, m.From)
+		if err != nil {
+			return err
+		}
+		if err := s.subtractCoins(m.From, types.Coins{types.Coin{Denom: m.Symbol, Amount: m.Amount}}); err != nil {
+			return err
+		}
+		token.TotalSupply -= types.Fixed8(m.Amount)
+	case msg.TokenFreezeMsg:
+		if _, err := s.token(m.Symbol); err != nil {
+			return err
+		}
+		coins := types.Coins{types.Coin{Denom: m.Symbol, Amount: m.Amount}}
+		if err := s.subtractCoins(m.From, coins); err != nil {
+			return err
+		}
+		acc := s.account(m.From)
+		acc.SetFrozenCoins(acc.GetFrozenCoins().Plus(coins))
+	case msg.TokenUnfreezeMsg:
+		acc := s.account(m.From)
+		if acc == nil {
+			return abciError(tx.CodespaceRoot, codeUnknownAddress, "account %s does not exist", m.From)
+		}
+		coins := types.Coins{types.Coin{Denom: m.Symbol, Amount: m.Amount}}
+		frozen := acc.GetFrozenCoins().Minus(coins)
+		if !frozen.IsNotNegative() {
+			return abciError(tx.CodespaceRoot, codeInsufficientCoins, "%s frozen is less than %s", acc.GetFrozenCoins(), coins)
+		}
+		acc.SetFrozenCoins(frozen)
+		acc.SetCoins(acc.GetCoins().Plus(coins))
+	case msg.TransferOwnershipMsg:
+		token, err := s.ownedToken(m.Symbol, m.From)
+		if err != nil {
+			return err
+		}
+		token.Owner = m.NewOwner
+	case msg.TimeLockMsg:
+		lockTime := time.Unix(m.LockTime, 0)
+		if !lockTime.After(ctx.time.Add(timelockMinTime)) {
+			return abciError(tx.CodespaceTimeLock, codeInvalidLockTime, "lock time(%s) should be %d minutes after the block time", lockTime.UTC(), timelockMinTime/time.Minute)
+		}
+		if err := s.subtractCoins(m.From, m.Amount); err != nil {
+			return err
+		}
+		records := s.timelocks[string(m.From)]
+		id := int64(1)
+		if len(records) > 0 {
+			id = records[len(records)-1].Id + 1
+		}
+		s.timelocks[string(m.From)] = append(records, types.TimeLockRecord{
+			Id:          id,
+			Description: m.Description,
+			Amount:      m.Amount.Sort(),
+			LockTime:    lockTime,
+		})
+		ctx.data = append(ctx.data, fmt.Sprintf("%d", id)...)
+	case msg.TimeRelockMsg:
+		i, err := s.timelock(m.From, m.Id)
+		if err != nil {
+			return err
+		}
+		record := s.timelocks[string(m.From)][i]
+		if m.Description != "" {
+			record.Description = m.Description
+		}
+		if m.LockTime != 0 {
+			lockTime := time.Unix(m.LockTime, 0)
+			if lockTime.Before(record.LockTime) || !lockTime.After(ctx.time.Add(timelockMinTime)) {
+				return abciError(tx.CodespaceTimeLock, codeInvalidRelock, "new lock time(%s) should be after the original one and %d minutes after the block time", lockTime.UTC(), timelockMinTime/time.Minute)
+			}
+			record.LockTime = lockTime
+		}
+		if !m.Amount.IsZero() {
+			amount := m.Amount.Sort()
+			if !amount.IsGTE(record.Amount) {
+				return abciError(tx.CodespaceTimeLock, codeInvalidRelock, "new locked amount(%s) should not be less than the original one(%s)", amount, record.Amount)
+			}
+			if err := s.subtractCoins(m.From, amount.Minus(record.Amount)); err != nil {
+				return err
+			}
+			record.Amount = amount
+		}
+		s.timelocks[string(m.From)][i] = record
+		ctx.data = append(ctx.data, fmt.Sprintf("%d", m.Id)...)
+	case msg.TimeUnlockMsg:
+		i, err := s.timelock(m.From, m.Id)
+		if err != nil {
+			return err
+		}
+		records := s.timelocks[string(m.From)]
+		record := records[i]
+		if ctx.time.Before(record.LockTime) {
+			return abciError(tx.CodespaceTimeLock, codeCanNotUnlock, "the coins are locked until %s", record.LockTime.UTC())
+		}
+		s.timelocks[string(m.From)] = append(records[:i:i], records[i+1:]...)
+		s.addCoins(m.From, record.Amount)
+		ctx.data = append(ctx.data, fmt.Sprintf("%d", m.Id)...)
+	case msg.HTLTMsg:
+		blockTime := ctx.time.Unix()
+		if m.Timestamp < blockTime-swapPastTimestamp || m.Timestamp > blockTime+swapFutureTimestamp {
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidTimestamp, "timestamp (%d) can neither be 15 minutes ahead of the current time (%d), nor 30 minutes later", m.Timestamp, blockTime)
+		}
+		id := types.SwapBytes(msg.CalculateSwapID(m.RandomNumberHash, m.From, m.SenderOtherChain))
+		if _, ok := s.swaps[string(id)]; ok {
+			return abciError(tx.CodespaceAtomicSwap, codeDuplicatedSwapID, "duplicated swapID %X", []byte(id))
+		}
+		if err := s.subtractCoins(m.From, m.Amount); err != nil {
+			return err
+		}
+		s.swaps[string(id)] = &types.AtomicSwap{
+			From:                m.From,
+			To:                  m.To,
+			OutAmount:           m.Amount.Sort(),
+			ExpectedIncome:      m.ExpectedIncome,
+			RecipientOtherChain: m.RecipientOtherChain,
+			RandomNumberHash:    m.RandomNumberHash,
+			Timestamp:           m.Timestamp,
+			ExpireHeight:        ctx.height + m.HeightSpan,
+			CrossChain:          m.CrossChain,
+			Status:              types.Open,
+			Index:               int64(len(s.swapOrder)),
+		}
+		s.swapOrder = append(s.swapOrder, id)
+		ctx.data = append(ctx.data, id...)
+	case msg.DepositHTLTMsg:
+		swap, err := s.swap(m.SwapID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case swap.CrossChain:
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidSingleChain, "can't deposit to cross chain swap")
+		case swap.Status != types.Open:
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidSingleChain, "expected swap status is open, actually it is %s", swap.Status)
+		case ctx.height >= swap.ExpireHeight:
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidSingleChain, "current block height is %d, the swap expire height(%d) is passed", ctx.height, swap.ExpireHeight)
+		case !bytes.Equal(swap.To, m.From):
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidSingleChain, "expected deposit from %s", swap.To)
+		case !swap.InAmount.IsZero():
+			return abciError(tx.CodespaceAtomicSwap, codeInvalidSingleChain, "can't deposit a swap for multiple times")
+		}
+		if err := s.subtractCoins(m.From, m.Amount); err != nil {
+			return err
+		}
+		swap.InAmount = m.Amount.Sort()
+	case msg.ClaimHTLTMsg:
+		swap, err := s.swap(m.SwapID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case swap.Status != types.Open:
+			return abciError(tx.CodespaceAtomicSwap, codeUnexpectedStatus, "expected swap status is open, actually it is %s", swap.Status)
+		case swap.ExpireHeight <= ctx.height:
+			return abciError(tx.CodespaceAtomicSwap, codeClaimExpiredSwap, "current block height is %d, the swap expire height(%d) is passed", ctx.height, swap.ExpireHeight)
+		case !bytes.Equal(msg.CalculateRandomHash(m.RandomNumber, swap.Timestamp), swap.RandomNumberHash):
+			return abciError(tx.CodespaceAtomicSwap, codeMismatchedRandom, "mismatched random number")
+		case !swap.CrossChain && swap.InAmount.IsZero():
+			return abciError(tx.CodespaceAtomicSwap, codeNotDepositedSwap, "can't claim a single chain swap which has not been deposited")
+		}
+		s.addCoins(swap.To, swap.OutAmount)
+		s.addCoins(swap.From, swap.InAmount)
+		swap.RandomNumber = m.RandomNumber
+		swap.Status = types.Completed
+		swap.ClosedTime = ctx.time.Unix()
+	case msg.RefundHTLTMsg:
+		swap, err := s.swap(m.SwapID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case swap.Status != types.Open:
+			return abciError(tx.CodespaceAtomicSwap, codeUnexpectedStatus, "expected swap status is open, actually it is %s", swap.Status)
+		case ctx.height < swap.ExpireHeight:
+			return abciError(tx.CodespaceAtomicSwap, codeRefundUnexpired, "current block height is %d, the expire height (%d) is still not reached", ctx.height, swap.ExpireHeight)
+		}
+		s.addCoins(swap.From, swap.OutAmount)
+		s.addCoins(swap.To, swap.InAmount)
+		swap.Status = types.Expired
+		swap.ClosedTime = ctx.time.Unix()
+	default:
+		return abciError(tx.CodespaceRoot, codeUnknownRequest, "unrecognized message type: %T", m)
+	}
+	return nil
+}
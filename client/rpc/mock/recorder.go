@@ -0,0 +1,489 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bnb-chain/go-sdk/common"
+)
+
+// RecordMode tells a Recorder whether to reach the endpoint or to replay a golden file.
+type RecordMode int
+
+const (
+	// ModeReplay serves the responses of the golden file, no endpoint is reached.
+	ModeReplay RecordMode = iota
+	// ModeRecord forwards the requests to the endpoint, and writes them with their
+	// responses to the golden file once closed.
+	ModeRecord
+)
+
+// Recording is the content of a golden file.
+type Recording struct {
+	HTTP       []*HTTPExchange     `json:"http,omitempty"`
+	Websockets []*WebsocketSession `json:"websockets,omitempty"`
+}
+
+// HTTPExchange is a request of the REST client and its response.
+type HTTPExchange struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request.
+	URL      string            `json:"url"`
+	Body     string            `json:"body,omitempty"`
+	Status   int               `json:"status"`
+	Header   map[string]string `json:"header,omitempty"`
+	Response string            `json:"response"`
+
+	used bool
+}
+
+// WebsocketSession is the messages of a websocket connection, in the order they went
+// through the recorder.
+type WebsocketSession struct {
+	Path     string              `json:"path"`
+	Messages []*WebsocketMessage `json:"messages"`
+
+	used bool
+}
+
+type WebsocketMessage struct {
+	// Sent is true for the messages of the client, false for those of the endpoint.
+	Sent bool   `json:"sent,omitempty"`
+	Data string `json:"data"`
+
+	used bool
+}
+
+// recordedHeaders are the response headers kept in the golden files.
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// Recorder is a local proxy of a node or REST API endpoint, recording the requests
+// and websocket messages of the clients to a golden file, or replaying them, so that
+// integration tests do not depend on the state of a live network. As it works on the
+// wire, every method of the RPC client, whose calls go through the websocket, and of
+// the REST clients is covered:
+//
+//	recorder, err := mock.NewRecorder("testdata/status.json", mock.ModeReplay, "tcp://data-seed-pre-0-s3.binance.org:80")
+//	defer recorder.Close()
+//	c := rpc.NewRPCClient(recorder.NodeURI(), types.TestNetwork)
+//
+// A request is replayed with the first unused response recorded for the same request,
+// or else for the same method and path, e.g. a broadcast of a tx signed at another
+// time. The JSON-RPC messages are matched on their method and params, the ids of the
+// responses and events being replaced by those of the replayed requests. The messages
+// sent by the endpoint after a JSON-RPC request are replayed after the matching one,
+// those before any request as soon as the websocket is connected.
+type Recorder struct {
+	*httptest.Server
+
+	mode      RecordMode
+	file      string
+	upstream  *url.URL
+	transport *common.TransportConfig
+	http      *http.Client
+
+	mtx       sync.Mutex
+	recording *Recording
+	conns     map[*websocket.Conn]struct{}
+	pumps     sync.WaitGroup
+}
+
+// NewRecorder starts a Recorder of file. In ModeRecord, the requests are forwarded
+// to endpoint, a node address such as tcp://host:port or an API host with an
+// optional scheme, https by default, reached with the transport options. In
+// ModeReplay, endpoint is not used and file must exist.
+func NewRecorder(file string, mode RecordMode, endpoint string, options ...common.TransportOption) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		file:      file,
+		transport: common.NewTransportConfig(options...),
+		recording: &Recording{},
+		conns:     make(map[*websocket.Conn]struct{}),
+	}
+	switch mode {
+	case ModeReplay:
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(bz, r.recording); err != nil {
+			return nil, fmt.Errorf("invalid golden file %s: %w", file, err)
+		}
+	case ModeRecord:
+		upstream, err := parseEndpoint(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		r.upstream = upstream
+		if r.http, err = r.transport.NewHTTPClient(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown record mode %d", mode)
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r, nil
+}
+
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "tcp":
+		u.Scheme = "http"
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("unsupported endpoint scheme %s", u.Scheme)
+	}
+	return u, nil
+}
+
+// Host is the base url to give the REST clients, with the http scheme.
+func (r *Recorder) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+// NodeURI is the node address to give the RPC client.
+func (r *Recorder) NodeURI() string {
+	return "tcp://" + r.Host()
+}
+
+// Close closes the connections and shuts the recorder down. In ModeRecord, the
+// golden file is then written.
+func (r *Recorder) Close() error {
+	r.mtx.Lock()
+	for conn := range r.conns {
+		conn.Close()
+	}
+	r.mtx.Unlock()
+	r.pumps.Wait()
+	r.Server.Close()
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mtx.Lock()
+	bz, err := json.MarshalIndent(r.recording, "", "  ")
+	r.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.file, append(bz, '\n'), 0644)
+}
+
+func (r *Recorder) serve(w http.ResponseWriter, req *http.Request) {
+	switch {
+	case websocket.IsWebSocketUpgrade(req) && r.mode == ModeRecord:
+		r.recordWebsocket(w, req)
+	case websocket.IsWebSocketUpgrade(req):
+		r.replayWebsocket(w, req)
+	case r.mode == ModeRecord:
+		r.recordHTTP(w, req)
+	default:
+		r.replayHTTP(w, req)
+	}
+}
+
+func (r *Recorder) recordHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, r.upstream.Scheme+"://"+r.upstream.Host+strings.TrimSuffix(r.upstream.Path, "/")+req.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for k, v := range req.Header {
+		out.Header[k] = v
+	}
+	for k, v := range r.transport.Headers {
+		out.Header.Set(k, v)
+	}
+	resp, err := r.http.Do(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	exchange := &HTTPExchange{
+		Method:   req.Method,
+		URL:      req.URL.RequestURI(),
+		Body:     string(body),
+		Status:   resp.StatusCode,
+		Response: string(response),
+	}
+	for _, k := range recordedHeaders {
+		if v := resp.Header.Get(k); v != "" {
+			if exchange.Header == nil {
+				exchange.Header = make(map[string]string)
+			}
+			exchange.Header[k] = v
+		}
+	}
+	r.mtx.Lock()
+	r.recording.HTTP = append(r.recording.HTTP, exchange)
+	r.mtx.Unlock()
+	writeExchange(w, exchange)
+}
+
+func writeExchange(w http.ResponseWriter, exchange *HTTPExchange) {
+	for k, v := range exchange.Header {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(exchange.Status)
+	_, _ = w.Write([]byte(exchange.Response))
+}
+
+func (r *Recorder) replayHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mtx.Lock()
+	var found *HTTPExchange
+	for _, exact := range []bool{true, false} {
+		for _, e := range r.recording.HTTP {
+			if e.used || e.Method != req.Method {
+				continue
+			}
+			if exact && e.URL == req.URL.RequestURI() && e.Body == string(body) ||
+				!exact && strings.SplitN(e.URL, "?", 2)[0] == req.URL.Path {
+				found = e
+				break
+			}
+		}
+		if found != nil {
+			break
+		}
+	}
+	if found != nil {
+		found.used = true
+	}
+	r.mtx.Unlock()
+	if found == nil {
+		http.Error(w, fmt.Sprintf("no recorded response for %s %s", req.Method, req.URL.RequestURI()), http.StatusNotImplemented)
+		return
+	}
+	writeExchange(w, found)
+}
+
+var recorderUpgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// track registers conn to be closed by Close.
+func (r *Recorder) track(conn *websocket.Conn) {
+	r.mtx.Lock()
+	r.conns[conn] = struct{}{}
+	r.mtx.Unlock()
+}
+
+func (r *Recorder) untrack(conn *websocket.Conn) {
+	r.mtx.Lock()
+	delete(r.conns, conn)
+	r.mtx.Unlock()
+	conn.Close()
+}
+
+func (r *Recorder) recordWebsocket(w http.ResponseWriter, req *http.Request) {
+	scheme := "ws"
+	if r.upstream.Scheme == "https" {
+		scheme = "wss"
+	}
+	upstreamURL := url.URL{Scheme: scheme, Host: r.upstream.Host, Path: strings.TrimSuffix(r.upstream.Path, "/") + req.URL.Path, RawQuery: req.URL.RawQuery}
+	upstream, _, err := r.transport.WebsocketDialer().Dial(upstreamURL.String(), r.transport.HTTPHeader())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	conn, err := recorderUpgrader.Upgrade(w, req, nil)
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	session := &WebsocketSession{Path: req.URL.RequestURI()}
+	r.mtx.Lock()
+	r.recording.Websockets = append(r.recording.Websockets, session)
+	r.mtx.Unlock()
+	r.track(conn)
+	r.track(upstream)
+
+	pump := func(from, to *websocket.Conn, sent bool) {
+		defer r.pumps.Done()
+		defer r.untrack(from)
+		defer r.untrack(to)
+		for {
+			kind, data, err := from.ReadMessage()
+			if err != nil {
+				return
+			}
+			r.mtx.Lock()
+			session.Messages = append(session.Messages, &WebsocketMessage{Sent: sent, Data: string(data)})
+			r.mtx.Unlock()
+			if err := to.WriteMessage(kind, data); err != nil {
+				return
+			}
+		}
+	}
+	r.pumps.Add(2)
+	go pump(conn, upstream, true)
+	go pump(upstream, conn, false)
+}
+
+// jsonRPCMessage is the part of the JSON-RPC requests and responses the replay
+// looks at.
+type jsonRPCMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+func parseJSONRPC(data string) (jsonRPCMessage, bool) {
+	var m jsonRPCMessage
+	if err := json.Unmarshal([]byte(data), &m); err != nil || m.Method == "" {
+		return m, false
+	}
+	var params bytes.Buffer
+	if json.Compact(&params, m.Params) == nil {
+		m.Params = params.Bytes()
+	}
+	return m, true
+}
+
+func (r *Recorder) replayWebsocket(w http.ResponseWriter, req *http.Request) {
+	r.mtx.Lock()
+	var session *WebsocketSession
+	for _, s := range r.recording.Websockets {
+		if !s.used && s.Path == req.URL.RequestURI() {
+			session, s.used = s, true
+			break
+		}
+	}
+	r.mtx.Unlock()
+	if session == nil {
+		http.Error(w, fmt.Sprintf("no recorded websocket for %s", req.URL.RequestURI()), http.StatusNotImplemented)
+		return
+	}
+	conn, err := recorderUpgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return
+	}
+	r.track(conn)
+	r.pumps.Add(1)
+	go func() {
+		defer r.pumps.Done()
+		defer r.untrack(conn)
+		// ids maps the ids of the recorded requests to those of the replayed ones
+		ids := make(map[string]json.RawMessage)
+		// replies sends the messages of the endpoint following the i-th one
+		replies := func(i int) error {
+			for _, m := range session.Messages[i:] {
+				if m.Sent {
+					return nil
+				}
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(replaceID(m.Data, ids))); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := replies(0); err != nil {
+			return
+		}
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			i := session.match(string(data))
+			if i < 0 {
+				continue
+			}
+			if live, ok := parseJSONRPC(string(data)); ok {
+				recorded, _ := parseJSONRPC(session.Messages[i].Data)
+				ids[string(recorded.ID)] = live.ID
+			}
+			if err := replies(i + 1); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// match marks as used and returns the index of the first unused message sent by the
+// client matching data, -1 if none.
+func (s *WebsocketSession) match(data string) int {
+	live, isRPC := parseJSONRPC(data)
+	for _, exact := range []bool{true, false} {
+		for i, m := range s.Messages {
+			if !m.Sent || m.used {
+				continue
+			}
+			matched := m.Data == data
+			if recorded, ok := parseJSONRPC(m.Data); ok && isRPC && recorded.Method == live.Method {
+				matched = !exact || bytes.Equal(recorded.Params, live.Params)
+			}
+			if matched {
+				m.used = true
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// replaceID gives the JSON-RPC response or event data the id of the replayed request.
+func replaceID(data string, ids map[string]json.RawMessage) string {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return data
+	}
+	var id string
+	if err := json.Unmarshal(m["id"], &id); err != nil {
+		return data
+	}
+	for recorded, live := range ids {
+		var recordedID, liveID string
+		if json.Unmarshal([]byte(recorded), &recordedID) != nil || json.Unmarshal(live, &liveID) != nil {
+			continue
+		}
+		switch id {
+		case recordedID:
+			m["id"] = live
+		case recordedID + "#event":
+			m["id"], _ = json.Marshal(liveID + "#event")
+		default:
+			continue
+		}
+		bz, err := json.Marshal(m)
+		if err != nil {
+			return data
+		}
+		return string(bz)
+	}
+	return data
+}
@@ -0,0 +1,129 @@
+package mock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	amino "github.com/tendermint/go-amino"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	sdk "github.com/bnb-chain/go-sdk/client"
+	"github.com/bnb-chain/go-sdk/client/basic"
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+// newNodeServer serves some routes of the node RPC over the chain, on /websocket.
+func newNodeServer(chain *FakeChain) *httptest.Server {
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	wm := rpcserver.NewWebsocketManager(map[string]*rpcserver.RPCFunc{
+		"status": rpcserver.NewRPCFunc(func(*rpctypes.Context) (*ctypes.ResultStatus, error) {
+			return chain.Status()
+		}, ""),
+		"abci_query": rpcserver.NewRPCFunc(func(_ *rpctypes.Context, path string, data cmn.HexBytes, height int64, prove bool) (*ctypes.ResultABCIQuery, error) {
+			return chain.ABCIQueryWithOptions(path, data, client.ABCIQueryOptions{Height: height, Prove: prove})
+		}, "path,data,height,prove"),
+		"block": rpcserver.NewRPCFunc(func(_ *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error) {
+			return chain.Block(height)
+		}, "height"),
+	}, cdc)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	return httptest.NewServer(mux)
+}
+
+func TestRecorderRPC(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	key := newTestKey(t)
+	chain.Fund(key.GetAddr(), bnb(1000))
+	node := newNodeServer(chain)
+	golden := filepath.Join(t.TempDir(), "rpc.json")
+
+	calls := func(nodeURI string) (*ctypes.ResultStatus, types.Account, *ctypes.ResultBlock) {
+		c := rpc.NewRPCClient(nodeURI, types.ProdNetwork)
+		defer c.Stop()
+		status, err := c.Status()
+		assert.NoError(t, err)
+		acc, err := c.GetAccount(key.GetAddr())
+		assert.NoError(t, err)
+		height := int64(1)
+		block, err := c.Block(&height)
+		assert.NoError(t, err)
+		return status, acc, block
+	}
+
+	recorder, err := NewRecorder(golden, ModeRecord, "tcp://"+node.Listener.Addr().String())
+	assert.NoError(t, err)
+	status, acc, block := calls(recorder.NodeURI())
+	assert.Equal(t, "test-chain", status.NodeInfo.Network)
+	assert.Equal(t, int64(1000), acc.GetCoins().AmountOf("BNB"))
+	assert.NoError(t, recorder.Close())
+
+	// the node has moved on, the replay still sees the recorded state
+	node.Close()
+	chain.Fund(key.GetAddr(), bnb(1000))
+	chain.ProduceBlocks(1)
+	replayer, err := NewRecorder(golden, ModeReplay, "")
+	assert.NoError(t, err)
+	defer replayer.Close()
+	replayedStatus, replayedAcc, replayedBlock := calls(replayer.NodeURI())
+	assert.Equal(t, status.SyncInfo, replayedStatus.SyncInfo)
+	assert.Equal(t, acc, replayedAcc)
+	assert.Equal(t, block.BlockMeta.BlockID, replayedBlock.BlockMeta.BlockID)
+}
+
+func TestRecorderREST(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice, bob := newTestKey(t), newTestKey(t)
+	chain.Fund(alice.GetAddr(), bnb(1000))
+	server := NewRESTServer(chain)
+	golden := filepath.Join(t.TempDir(), "testdata", "rest.json")
+
+	calls := func(host string) (*types.BalanceAccount, string, error) {
+		c, err := sdk.NewDexClient(host, types.ProdNetwork, alice, basic.WithTransport(common.WithScheme("http")))
+		if !assert.NoError(t, err) {
+			return nil, "", err
+		}
+		sent, err := c.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(300)}}, true)
+		assert.NoError(t, err)
+		acc, err := c.GetAccount(bob.GetAddr().String())
+		assert.NoError(t, err)
+		_, err = c.GetTx("00")
+		return acc, sent.Hash, err
+	}
+
+	recorder, err := NewRecorder(golden, ModeRecord, server.URL)
+	assert.NoError(t, err)
+	acc, hash, notFound := calls(recorder.Host())
+	assert.Equal(t, types.Fixed8(300), acc.Balances[0].Free)
+	assert.Error(t, notFound)
+	assert.NoError(t, recorder.Close())
+
+	server.Close()
+	replayer, err := NewRecorder(golden, ModeReplay, "")
+	assert.NoError(t, err)
+	defer replayer.Close()
+	replayedAcc, replayedHash, replayedNotFound := calls(replayer.Host())
+	assert.Equal(t, acc, replayedAcc)
+	assert.Equal(t, hash, replayedHash)
+	assert.Equal(t, notFound, replayedNotFound)
+
+	// every response is replayed once
+	resp, err := replayer.Server.Client().Get(replayer.URL + "/api/v1/node-info")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	_, err = NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, "")
+	assert.Error(t, err)
+}
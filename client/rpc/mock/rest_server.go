@@ -0,0 +1,522 @@
+package mock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	gtypes "github.com/bnb-chain/go-sdk/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+const (
+	defaultTokensLimit = 500
+	maxTokensLimit     = 1000
+	// restStreamBuffer is how many messages a websocket stream may lag behind
+	// before it is closed.
+	restStreamBuffer  = 100
+	blockHeightStream = "$all@blockheight"
+)
+
+// RESTServer is a local stand-in of the REST API, serving the accounts, tokens and
+// txs of a FakeChain and broadcasting txs to it, so that the basic, query and
+// transaction clients can be tested without a public endpoint:
+//
+//	server := mock.NewRESTServer(chain)
+//	defer server.Close()
+//	dexClient, err := client.NewDexClient(server.Host(), types.ProdNetwork, keyManager,
+//		basic.WithTransport(common.WithScheme("http")))
+//
+// It serves /node-info, /account/{address}, /tokens, /mini/tokens, /time, /tx/{hash}
+// and /broadcast under /api/v1, and /internal/api/v1 as well. The chain has no mini
+// token, so /mini/tokens is always empty. A broadcast with sync=true waits for the
+// tx to be committed, otherwise it only reports the checks, and failed checks are
+// answered with 400 like the API does.
+//
+// The websocket streams under /api/ws are $all@blockheight, sending a
+// BlockHeightEvent for every block, and {address}, sending an AccountEvent when the
+// balances of the address change and a TransferEvent for each transfer from or to
+// it. A stream falling restStreamBuffer messages behind is closed.
+type RESTServer struct {
+	*httptest.Server
+
+	chain *FakeChain
+
+	mtx     sync.Mutex
+	streams map[*restStream]struct{}
+}
+
+type restStream struct {
+	// addr is nil for the block height stream
+	addr     types.AccAddress
+	balances []EventBalance
+	out      chan wsMessage
+}
+
+type wsMessage struct {
+	Stream string      `json:"stream"`
+	Data   interface{} `json:"data"`
+}
+
+// BlockHeightEvent is sent on the $all@blockheight stream.
+type BlockHeightEvent struct {
+	BlockHeight int64 `json:"h"`
+}
+
+// AccountEvent is sent on the stream of an address with its new balances.
+type AccountEvent struct {
+	EventType   string         `json:"e"`
+	EventHeight int64          `json:"E"`
+	Balances    []EventBalance `json:"B"`
+}
+
+type EventBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Frozen string `json:"r"`
+	Locked string `json:"l"`
+}
+
+// TransferEvent is sent on the streams of the sender and the receivers of a transfer.
+type TransferEvent struct {
+	EventType   string          `json:"e"`
+	EventHeight int64           `json:"E"`
+	TxHash      string          `json:"H"`
+	Memo        string          `json:"M"`
+	FromAddr    string          `json:"f"`
+	Transfers   []EventTransfer `json:"t"`
+}
+
+type EventTransfer struct {
+	ToAddr string      `json:"o"`
+	Coins  []EventCoin `json:"c"`
+}
+
+type EventCoin struct {
+	Asset  string `json:"a"`
+	Amount string `json:"A"`
+}
+
+// NewRESTServer starts a RESTServer over chain, to be closed once done.
+func NewRESTServer(chain *FakeChain) *RESTServer {
+	s := &RESTServer{chain: chain, streams: make(map[*restStream]struct{})}
+	api := http.NewServeMux()
+	api.HandleFunc("/api/v1/node-info", s.nodeInfo)
+	api.HandleFunc("/api/v1/account/", s.account)
+	api.HandleFunc("/api/v1/tokens", s.tokens)
+	api.HandleFunc("/api/v1/mini/tokens", s.miniTokens)
+	api.HandleFunc("/api/v1/time", s.currentTime)
+	api.HandleFunc("/api/v1/tx/", s.txResult)
+	api.HandleFunc("/api/v1/broadcast", s.broadcast)
+	api.HandleFunc(gtypes.DefaultWSPrefix+"/", s.stream)
+	mux := http.NewServeMux()
+	mux.Handle("/internal/", http.StripPrefix("/internal", api))
+	mux.Handle("/", api)
+	s.Server = httptest.NewServer(mux)
+	chain.onCommit(s.onCommit)
+	return s
+}
+
+// Host is the base url to give the clients, with the http scheme.
+func (s *RESTServer) Host() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Close closes the websocket streams and shuts the server down.
+func (s *RESTServer) Close() {
+	s.mtx.Lock()
+	for stream := range s.streams {
+		s.closeStream(stream)
+	}
+	s.mtx.Unlock()
+	s.Server.Close()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]interface{}{"code": status, "message": fmt.Sprintf(format, args...)})
+}
+
+// writeCheckTxError answers a tx failing the checks, the ABCI error nested in the message.
+func writeCheckTxError(w http.ResponseWriter, code uint32, log string) {
+	nested, _ := json.Marshal(struct {
+		Codespace uint32 `json:"codespace"`
+		Code      uint32 `json:"code"`
+		ABCICode  uint32 `json:"abci_code"`
+		Message   string `json:"message"`
+	}{code >> 16, code & 0xFFFF, code, log})
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{"code": http.StatusBadRequest, "failed_tx_index": 0, "message": string(nested)})
+}
+
+func (s *RESTServer) nodeInfo(w http.ResponseWriter, r *http.Request) {
+	status, err := s.chain.Status()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, types.ResultStatus{
+		NodeInfo: types.NodeInfo{
+			ID:      string(status.NodeInfo.ID()),
+			Network: status.NodeInfo.Network,
+			Moniker: status.NodeInfo.Moniker,
+		},
+		SyncInfo: types.SyncInfo{
+			LatestBlockHash:   status.SyncInfo.LatestBlockHash,
+			LatestAppHash:     status.SyncInfo.LatestAppHash,
+			LatestBlockHeight: status.SyncInfo.LatestBlockHeight,
+			LatestBlockTime:   status.SyncInfo.LatestBlockTime,
+		},
+	})
+}
+
+func (s *RESTServer) account(w http.ResponseWriter, r *http.Request) {
+	addr, err := types.AccAddressFromBech32(strings.TrimPrefix(r.URL.Path, "/api/v1/account/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid address: %v", err)
+		return
+	}
+	f := s.chain
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	acc := f.state.account(addr)
+	if acc == nil {
+		writeError(w, http.StatusNotFound, "account not found")
+		return
+	}
+	balances := make([]types.TokenBalance, 0, len(acc.GetCoins()))
+	for _, coin := range acc.GetCoins() {
+		balances = append(balances, balance(acc, coin.Denom))
+	}
+	var pubKey []byte
+	if acc.GetPubKey() != nil {
+		pubKey = acc.GetPubKey().Bytes()
+	}
+	writeJSON(w, http.StatusOK, types.BalanceAccount{
+		Number:    acc.GetAccountNumber(),
+		Address:   addr.String(),
+		Balances:  balances,
+		PublicKey: pubKey,
+		Sequence:  acc.GetSequence(),
+		Flags:     acc.GetFlags(),
+	})
+}
+
+// pageParams parses the offset and limit query parameters.
+func pageParams(r *http.Request) (offset, limit int, err error) {
+	limit = defaultTokensLimit
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid offset %q", v)
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > maxTokensLimit {
+			return 0, 0, fmt.Errorf("invalid limit %q", v)
+		}
+	}
+	return offset, limit, nil
+}
+
+func (s *RESTServer) tokens(w http.ResponseWriter, r *http.Request) {
+	offset, limit, err := pageParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	s.chain.mtx.Lock()
+	tokens := s.chain.tokens(offset, limit)
+	s.chain.mtx.Unlock()
+	writeJSON(w, http.StatusOK, tokens)
+}
+
+func (s *RESTServer) miniTokens(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := pageParams(r); err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, []types.MiniToken{})
+}
+
+func (s *RESTServer) currentTime(w http.ResponseWriter, r *http.Request) {
+	status, err := s.chain.Status()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, types.Time{
+		ApTime:    time.Now().UTC().Format(time.RFC3339),
+		BlockTime: status.SyncInfo.LatestBlockTime.UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *RESTServer) txResult(w http.ResponseWriter, r *http.Request) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(r.URL.Path, "/api/v1/tx/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid tx hash: %v", err)
+		return
+	}
+	res, err := s.chain.Tx(hash, false)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tx.TxResult{
+		Hash: fmt.Sprintf("%X", []byte(res.Hash)),
+		Log:  res.TxResult.Log,
+		Data: s.commitData(res.Tx, res.TxResult.Data),
+		Code: int32(res.TxResult.Code),
+	})
+}
+
+func (s *RESTServer) broadcast(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	bz, err := hex.DecodeString(strings.TrimSpace(string(body)))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "tx is not hex encoded: %v", err)
+		return
+	}
+	if sync, _ := strconv.ParseBool(r.URL.Query().Get("sync")); sync {
+		res, err := s.chain.BroadcastTxCommit(bz)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if res.CheckTx.IsErr() {
+			writeCheckTxError(w, res.CheckTx.Code, res.CheckTx.Log)
+			return
+		}
+		writeJSON(w, http.StatusOK, []tx.TxCommitResult{{
+			Ok:   res.DeliverTx.Code == 0,
+			Log:  res.DeliverTx.Log,
+			Hash: fmt.Sprintf("%X", []byte(res.Hash)),
+			Code: int32(res.DeliverTx.Code),
+			Data: s.commitData(bz, res.DeliverTx.Data),
+		}})
+		return
+	}
+	res, err := s.chain.BroadcastTxSync(bz)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if res.Code != 0 {
+		writeCheckTxError(w, res.Code, res.Log)
+		return
+	}
+	writeJSON(w, http.StatusOK, []tx.TxCommitResult{{Ok: true, Hash: res.Hash.String()}})
+}
+
+// commitData is the data of a committed tx as the API reports it: the token issued
+// has its total supply as a string.
+func (s *RESTServer) commitData(bz []byte, data []byte) string {
+	var stdTx tx.StdTx
+	if err := s.chain.cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx); err != nil || len(stdTx.Msgs) != 1 {
+		return string(data)
+	}
+	if _, ok := stdTx.Msgs[0].(msg.TokenIssueMsg); !ok {
+		return string(data)
+	}
+	var token types.Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return string(data)
+	}
+	issued, _ := json.Marshal(map[string]string{
+		"name":            token.Name,
+		"symbol":          token.Symbol,
+		"original_symbol": token.OrigSymbol,
+		"total_supply":    token.TotalSupply.String(),
+		"owner":           token.Owner.String(),
+	})
+	return string(issued)
+}
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+func (s *RESTServer) stream(w http.ResponseWriter, r *http.Request) {
+	stream := &restStream{out: make(chan wsMessage, restStreamBuffer)}
+	if name := strings.TrimPrefix(r.URL.Path, gtypes.DefaultWSPrefix+"/"); name != blockHeightStream {
+		addr, err := types.AccAddressFromBech32(name)
+		if err != nil {
+			writeError(w, http.StatusNotFound, "unknown stream %q", name)
+			return
+		}
+		stream.addr = addr
+	}
+	// the stream is registered before the handshake is answered, so that the blocks
+	// committed once the client is connected are not missed
+	s.chain.mtx.Lock()
+	if stream.addr != nil {
+		stream.balances = eventBalances(s.chain.state.account(stream.addr))
+	}
+	s.mtx.Lock()
+	s.streams[stream] = struct{}{}
+	s.mtx.Unlock()
+	s.chain.mtx.Unlock()
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.mtx.Lock()
+		s.closeStream(stream)
+		s.mtx.Unlock()
+		return
+	}
+
+	go func() {
+		defer conn.Close()
+		for m := range stream.out {
+			if err := conn.WriteJSON(m); err != nil {
+				return
+			}
+		}
+		_ = conn.WriteControl(websocket.CloseMessage, nil, time.Now().Add(time.Second))
+	}()
+	go func() {
+		// the keep alive messages are ignored, pings are answered by the default handler
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				break
+			}
+		}
+		s.mtx.Lock()
+		s.closeStream(stream)
+		s.mtx.Unlock()
+	}()
+}
+
+// closeStream must be called with s.mtx held.
+func (s *RESTServer) closeStream(stream *restStream) {
+	if _, ok := s.streams[stream]; ok {
+		delete(s.streams, stream)
+		close(stream.out)
+	}
+}
+
+// onCommit sends the messages of block to the streams, with the chain locked.
+func (s *RESTServer) onCommit(block *fakeBlock) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.streams) == 0 {
+		return
+	}
+	height := block.block.Height
+	transfers := s.transfers(block)
+	for stream := range s.streams {
+		var messages []wsMessage
+		if stream.addr == nil {
+			messages = append(messages, wsMessage{Stream: "blockheight", Data: BlockHeightEvent{BlockHeight: height}})
+		} else {
+			balances := eventBalances(s.chain.state.account(stream.addr))
+			if !reflect.DeepEqual(balances, stream.balances) {
+				stream.balances = balances
+				messages = append(messages, wsMessage{Stream: "accounts", Data: AccountEvent{EventType: "outboundAccountInfo", EventHeight: height, Balances: balances}})
+			}
+			for _, t := range transfers {
+				if t.involves(stream.addr) {
+					messages = append(messages, wsMessage{Stream: "transfers", Data: t.event})
+				}
+			}
+		}
+		for _, m := range messages {
+			select {
+			case stream.out <- m:
+			default:
+				s.closeStream(stream)
+			}
+			if _, ok := s.streams[stream]; !ok {
+				break
+			}
+		}
+	}
+}
+
+type blockTransfer struct {
+	event TransferEvent
+	addrs []types.AccAddress
+}
+
+func (t blockTransfer) involves(addr types.AccAddress) bool {
+	for _, a := range t.addrs {
+		if a.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// transfers lists the send msgs of the successful txs of block.
+func (s *RESTServer) transfers(block *fakeBlock) []blockTransfer {
+	var transfers []blockTransfer
+	for i, t := range block.block.Data.Txs {
+		if block.results[i].Code != 0 {
+			continue
+		}
+		var stdTx tx.StdTx
+		if err := s.chain.cdc.UnmarshalBinaryLengthPrefixed(t, &stdTx); err != nil {
+			continue
+		}
+		for _, m := range stdTx.Msgs {
+			send, ok := m.(msg.SendMsg)
+			if !ok || len(send.Inputs) == 0 {
+				continue
+			}
+			transfer := blockTransfer{event: TransferEvent{
+				EventType:   "outboundTransferInfo",
+				EventHeight: block.block.Height,
+				TxHash:      fmt.Sprintf("%X", t.Hash()),
+				Memo:        stdTx.Memo,
+				FromAddr:    send.Inputs[0].Address.String(),
+			}}
+			for _, in := range send.Inputs {
+				transfer.addrs = append(transfer.addrs, in.Address)
+			}
+			for _, out := range send.Outputs {
+				transfer.addrs = append(transfer.addrs, out.Address)
+				coins := make([]EventCoin, 0, len(out.Coins))
+				for _, coin := range out.Coins {
+					coins = append(coins, EventCoin{Asset: coin.Denom, Amount: types.Fixed8(coin.Amount).String()})
+				}
+				transfer.event.Transfers = append(transfer.event.Transfers, EventTransfer{ToAddr: out.Address.String(), Coins: coins})
+			}
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers
+}
+
+// eventBalances returns the balances of acc, which may be nil, by asset.
+func eventBalances(acc *types.AppAccount) []EventBalance {
+	if acc == nil {
+		return nil
+	}
+	all := acc.GetCoins().Plus(acc.GetFrozenCoins()).Plus(acc.GetLockedCoins())
+	balances := make([]EventBalance, 0, len(all))
+	for _, coin := range all {
+		b := balance(acc, coin.Denom)
+		balances = append(balances, EventBalance{Asset: b.Symbol, Free: b.Free.String(), Frozen: b.Frozen.String(), Locked: b.Locked.String()})
+	}
+	return balances
+}
@@ -0,0 +1,130 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/client"
+	"github.com/bnb-chain/go-sdk/client/basic"
+	"github.com/bnb-chain/go-sdk/client/transaction"
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func receive(t *testing.T, ch <-chan interface{}) interface{} {
+	select {
+	case m := <-ch:
+		assert.NotNil(t, m)
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("no websocket message")
+		return nil
+	}
+}
+
+func TestRESTServer(t *testing.T) {
+	chain := NewFakeChain("test-chain")
+	alice, bob := newTestKey(t), newTestKey(t)
+	chain.Fund(alice.GetAddr(), bnb(1000))
+	server := NewRESTServer(chain)
+	defer server.Close()
+
+	dexClient, err := client.NewDexClient(server.Host(), types.ProdNetwork, alice, basic.WithTransport(common.WithScheme("http")))
+	assert.NoError(t, err)
+
+	closeCh := make(chan struct{})
+	defer close(closeCh)
+	heights, err := dexClient.WsGet(blockHeightStream, func(bz []byte) (interface{}, error) {
+		var event BlockHeightEvent
+		err := json.Unmarshal(bz, &event)
+		return &event, err
+	}, closeCh)
+	assert.NoError(t, err)
+	bobEvents, err := dexClient.WsGet(bob.GetAddr().String(), func(bz []byte) (interface{}, error) {
+		var event struct {
+			EventType   string `json:"e"`
+			EventHeight int64  `json:"E"`
+		}
+		if err := json.Unmarshal(bz, &event); err != nil {
+			return nil, err
+		}
+		if event.EventType == "outboundTransferInfo" {
+			var transfer TransferEvent
+			return &transfer, json.Unmarshal(bz, &transfer)
+		}
+		var account AccountEvent
+		return &account, json.Unmarshal(bz, &account)
+	}, closeCh)
+	assert.NoError(t, err)
+
+	sent, err := dexClient.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(300)}}, true, transaction.WithMemo("hello"))
+	assert.NoError(t, err)
+	assert.True(t, sent.Ok, sent.Log)
+	acc, err := dexClient.GetAccount(bob.GetAddr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, []types.TokenBalance{{Symbol: "BNB", Free: 300, Locked: 0, Frozen: 0}}, acc.Balances)
+	acc, err = dexClient.GetAccount(alice.GetAddr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), acc.Sequence)
+	assert.NotEmpty(t, acc.PublicKey)
+	found, err := dexClient.GetTx(sent.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.CodeOk, found.Code)
+
+	height := receive(t, heights).(*BlockHeightEvent)
+	assert.Equal(t, int64(2), height.BlockHeight)
+	account := receive(t, bobEvents).(*AccountEvent)
+	assert.Equal(t, []EventBalance{{Asset: "BNB", Free: "0.00000300", Frozen: "0.00000000", Locked: "0.00000000"}}, account.Balances)
+	transfer := receive(t, bobEvents).(*TransferEvent)
+	assert.Equal(t, sent.Hash, transfer.TxHash)
+	assert.Equal(t, "hello", transfer.Memo)
+	assert.Equal(t, alice.GetAddr().String(), transfer.FromAddr)
+	assert.Equal(t, []EventTransfer{{ToAddr: bob.GetAddr().String(), Coins: []EventCoin{{Asset: "BNB", Amount: "0.00000300"}}}}, transfer.Transfers)
+
+	issued, err := dexClient.IssueToken("Test Token", "TST", 1000, true, true)
+	assert.NoError(t, err)
+	assert.True(t, issued.Ok, issued.Log)
+	tokens, err := dexClient.GetTokens(types.NewTokensQuery().WithOffset(1))
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, issued.Symbol, tokens[0].Symbol)
+	miniTokens, err := dexClient.GetMiniTokens(types.NewTokensQuery())
+	assert.NoError(t, err)
+	assert.Empty(t, miniTokens)
+
+	now, err := dexClient.GetTime()
+	assert.NoError(t, err)
+	blockTime, err := time.Parse(time.RFC3339, now.BlockTime)
+	assert.NoError(t, err)
+	locked, err := dexClient.TimeLock("locked", bnb(100), blockTime.Add(time.Hour).Unix(), true)
+	assert.NoError(t, err)
+	assert.True(t, locked.Ok, locked.Log)
+	assert.Equal(t, int64(1), locked.LockId)
+
+	// failed checks are answered with 400, failed msgs once committed
+	_, err = dexClient.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(1)}}, true, transaction.WithAcNumAndSequence(0, 0))
+	assert.True(t, errors.Is(err, tx.ErrInvalidSequence), "%v", err)
+	failed, err := dexClient.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(5000)}}, true)
+	assert.NoError(t, err)
+	assert.False(t, failed.Ok)
+	assert.True(t, errors.Is(tx.NewABCIError(uint32(failed.Code), failed.Log), tx.ErrInsufficientFunds), failed.Log)
+
+	async, err := dexClient.SendToken([]msg.Transfer{{ToAddr: bob.GetAddr(), Coins: bnb(1)}}, false)
+	assert.NoError(t, err)
+	assert.True(t, async.Ok)
+	acc, err = dexClient.GetAccount(bob.GetAddr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, types.Fixed8(301), acc.Balances[0].Free)
+
+	acc, err = dexClient.GetAccount(newTestKey(t).GetAddr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, &types.BalanceAccount{}, acc)
+	_, err = dexClient.GetTx("00")
+	assert.True(t, errors.Is(err, tx.ErrNotFound), "%v", err)
+}
@@ -1,11 +1,18 @@
 package rpc
 
-import "github.com/bnb-chain/go-sdk/common/types"
+import (
+	"context"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+)
 
 type OpsClient interface {
 	IsActive() bool
 	GetStakeValidators() ([]types.Validator, error)
 	GetDelegatorUnbondingDelegations(delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error)
+
+	GetStakeValidatorsCtx(ctx context.Context) ([]types.Validator, error)
+	GetDelegatorUnbondingDelegationsCtx(ctx context.Context, delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error)
 }
 
 func (c *HTTP) IsActive() bool {
@@ -13,7 +20,11 @@ func (c *HTTP) IsActive() bool {
 }
 
 func (c *HTTP) GetStakeValidators() ([]types.Validator, error) {
-	rawVal, err := c.ABCIQuery("custom/stake/validators", nil)
+	return c.GetStakeValidatorsCtx(context.Background())
+}
+
+func (c *HTTP) GetStakeValidatorsCtx(ctx context.Context) ([]types.Validator, error) {
+	rawVal, err := c.ABCIQueryCtx(ctx, "custom/stake/validators", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -24,6 +35,10 @@ func (c *HTTP) GetStakeValidators() ([]types.Validator, error) {
 }
 
 func (c *HTTP) GetDelegatorUnbondingDelegations(delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	return c.GetDelegatorUnbondingDelegationsCtx(context.Background(), delegatorAddr)
+}
+
+func (c *HTTP) GetDelegatorUnbondingDelegationsCtx(ctx context.Context, delegatorAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
 	param := struct {
 		DelegatorAddr types.AccAddress
 	}{delegatorAddr}
@@ -32,7 +47,7 @@ func (c *HTTP) GetDelegatorUnbondingDelegations(delegatorAddr types.AccAddress)
 		return nil, err
 	}
 
-	rawDel, err := c.ABCIQuery("custom/stake/delegatorUnbondingDelegations", bz)
+	rawDel, err := c.ABCIQueryCtx(ctx, "custom/stake/delegatorUnbondingDelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,328 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxReplayBlocks bounds the NewBlock events replayed after a reconnect, as they are
+// fetched block by block.
+const maxReplayBlocks = 1000
+
+const replayPerPage = 100
+
+// Gap reports that the websocket was reconnected while subscribed to Query, so that
+// the events between LastHeight and Height may have been missed.
+type Gap struct {
+	Query string
+	// LastHeight is the height of the last event received, or the height when
+	// subscribing if none was received and replay is enabled.
+	LastHeight int64
+	// Height is the latest height of the node once reconnected, 0 if unknown.
+	Height int64
+	// Replayed reports whether the missed events were sent on the subscription
+	// channel, before the live ones.
+	Replayed bool
+	// Err is the failure to get the height or to replay the events.
+	Err error
+}
+
+// SetGapHandler sets the function told about every gap in the subscriptions. It is
+// called from the goroutine delivering the events, so it must not block.
+func (w *WSEvents) SetGapHandler(handler func(Gap)) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.onGap = handler
+}
+
+// SetReplay enables the replay of the events missed while reconnecting, for the
+// subscriptions made after. Tx events are found with TxSearch and NewBlock events
+// with Block and BlockResults, so only queries on tm.event='Tx', and queries on
+// tm.event='NewBlock' alone, can be replayed.
+func (w *WSEvents) SetReplay(enabled bool) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.replay = enabled
+}
+
+// forwardEvents decodes the events of sub from in to out. While a gap is handled, the
+// live events are held back and the ones already replayed are dropped.
+func (w *WSEvents) forwardEvents(sub *subscription, in chan rpctypes.RPCResponse, out chan ctypes.ResultEvent) {
+	var (
+		gaps     = sub.gaps
+		gapDone  chan int64
+		pending  []ctypes.ResultEvent
+		replayed int64
+	)
+	send := func(event ctypes.ResultEvent) bool {
+		if height := eventHeight(event); height > 0 {
+			if height <= replayed {
+				return true
+			}
+			if height > sub.lastHeight {
+				sub.lastHeight = height
+			}
+		}
+		select {
+		case out <- event:
+			return true
+		case <-sub.quit:
+			return false
+		}
+	}
+	for {
+		select {
+		case <-sub.quit:
+			return
+		case <-gaps:
+			gaps, gapDone = nil, make(chan int64, 1)
+			go func(lastHeight int64) {
+				gapDone <- w.closeGap(sub, lastHeight, out)
+			}(sub.lastHeight)
+		case height := <-gapDone:
+			gaps, gapDone = sub.gaps, nil
+			if height > replayed {
+				replayed = height
+			}
+			if height > sub.lastHeight {
+				sub.lastHeight = height
+			}
+			for _, event := range pending {
+				if !send(event) {
+					return
+				}
+			}
+			pending = nil
+		case resp, ok := <-in:
+			if !ok {
+				w.Logger.Info("channel of event stream is closed", "request id", sub.id)
+				return
+			}
+			if resp.Error != nil {
+				w.Logger.Error("receive error from event stream", "error", resp.Error)
+				continue
+			}
+			res := new(ctypes.ResultEvent)
+			err := w.cdc.UnmarshalJSON(resp.Result, res)
+			if err != nil {
+				w.Logger.Debug("receive unexpected data from event stream", "result", resp.Result)
+				continue
+			}
+			if gapDone != nil {
+				pending = append(pending, *res)
+				continue
+			}
+			if !send(*res) {
+				return
+			}
+		}
+	}
+}
+
+// closeGap replays the events of sub after lastHeight if enabled, and reports the gap.
+// It returns the height up to which the events were replayed.
+func (w *WSEvents) closeGap(sub *subscription, lastHeight int64, out chan ctypes.ResultEvent) int64 {
+	w.mtx.RLock()
+	onGap, replay := w.onGap, w.replay
+	w.mtx.RUnlock()
+	gap := Gap{Query: sub.query, LastHeight: lastHeight}
+	if onGap == nil && !replay {
+		return 0
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-sub.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	var replayed int64
+	status, err := w.StatusCtx(ctx)
+	if err != nil {
+		gap.Err = err
+	} else {
+		gap.Height = status.SyncInfo.LatestBlockHeight
+		if replay && lastHeight > 0 && gap.Height > lastHeight {
+			replayed, gap.Err = w.replayEvents(ctx, sub.query, lastHeight+1, gap.Height, out)
+			gap.Replayed = gap.Err == nil
+		}
+	}
+	if gap.Err != nil {
+		w.Logger.Error("events may have been missed while reconnecting", "query", sub.query, "last height", lastHeight, "err", gap.Err)
+	}
+	if onGap != nil {
+		onGap(gap)
+	}
+	return replayed
+}
+
+// replayEvents sends the events of q from height from to height to on out. It returns
+// the height up to which all the events were sent.
+func (w *WSEvents) replayEvents(ctx context.Context, q string, from, to int64, out chan ctypes.ResultEvent) (int64, error) {
+	event, txQuery, err := replayQuery(q)
+	if err != nil {
+		return 0, err
+	}
+	send := func(e ctypes.ResultEvent) error {
+		select {
+		case out <- e:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if event == types.EventNewBlock {
+		if to-from >= maxReplayBlocks {
+			return 0, fmt.Errorf("can't replay %d blocks, at most %d are", to-from+1, maxReplayBlocks)
+		}
+		for height := from; height <= to; height++ {
+			e, err := w.newBlockEvent(ctx, q, height)
+			if err == nil {
+				err = send(e)
+			}
+			if err != nil {
+				return height - 1, err
+			}
+		}
+		return to, nil
+	}
+
+	search := fmt.Sprintf("tx.height>=%d AND tx.height<=%d", from, to)
+	if txQuery != "" {
+		search = txQuery + " AND " + search
+	}
+	var txs []*ResultTx
+	for page := 1; ; page++ {
+		res, err := w.TxSearchCtx(ctx, search, false, page, replayPerPage)
+		if err != nil {
+			return 0, err
+		}
+		txs = append(txs, res.Txs...)
+		if len(res.Txs) < replayPerPage || len(txs) >= res.TotalCount {
+			break
+		}
+	}
+	for _, t := range txs {
+		if err := send(replayedTxEvent(q, t)); err != nil {
+			return 0, err
+		}
+	}
+	return to, nil
+}
+
+func (w *WSEvents) newBlockEvent(ctx context.Context, q string, height int64) (ctypes.ResultEvent, error) {
+	block, err := w.BlockCtx(ctx, &height)
+	if err != nil {
+		return ctypes.ResultEvent{}, err
+	}
+	results, err := w.BlockResultsCtx(ctx, &height)
+	if err != nil {
+		return ctypes.ResultEvent{}, err
+	}
+	data := types.EventDataNewBlock{Block: block.Block}
+	if results.Results != nil {
+		if r := results.Results.BeginBlock; r != nil {
+			data.ResultBeginBlock = abci.ResponseBeginBlock{Events: r.Events}
+		}
+		if r := results.Results.EndBlock; r != nil {
+			data.ResultEndBlock = abci.ResponseEndBlock{
+				ValidatorUpdates:      r.ValidatorUpdates,
+				ConsensusParamUpdates: r.ConsensusParamUpdates,
+				Events:                r.Events,
+			}
+		}
+	}
+	return ctypes.ResultEvent{
+		Query:  q,
+		Data:   data,
+		Events: map[string][]string{types.EventTypeKey: {types.EventNewBlock}},
+	}, nil
+}
+
+func replayedTxEvent(q string, t *ResultTx) ctypes.ResultEvent {
+	r := t.TxResult
+	return ctypes.ResultEvent{
+		Query: q,
+		Data: types.EventDataTx{TxResult: types.TxResult{
+			Height: t.Height,
+			Index:  t.Index,
+			Tx:     t.Tx,
+			Result: abci.ResponseDeliverTx{
+				Code:      r.Code,
+				Data:      r.Data,
+				Log:       r.Log,
+				Info:      r.Info,
+				GasWanted: r.GasWanted,
+				GasUsed:   r.GasUsed,
+				Events:    r.Events,
+				Codespace: r.Codespace,
+			},
+		}},
+		Events: map[string][]string{
+			types.EventTypeKey: {types.EventTx},
+			types.TxHashKey:    {fmt.Sprintf("%X", t.Hash)},
+			types.TxHeightKey:  {strconv.FormatInt(t.Height, 10)},
+		},
+	}
+}
+
+// replayQuery returns the event type of q, and for Tx events the conditions of q
+// other than tm.event, to be used with TxSearch.
+func replayQuery(q string) (string, string, error) {
+	parsed, err := query.New(q)
+	if err != nil {
+		return "", "", err
+	}
+	conditions, err := parsed.Conditions()
+	if err != nil {
+		return "", "", err
+	}
+	var event string
+	var others []string
+	for _, c := range conditions {
+		if c.Tag == types.EventTypeKey && c.Op == query.OpEqual {
+			event, _ = c.Operand.(string)
+			continue
+		}
+		switch operand := c.Operand.(type) {
+		case string:
+			others = append(others, fmt.Sprintf("%s %s '%s'", c.Tag, c.Op, operand))
+		case int64, float64:
+			others = append(others, fmt.Sprintf("%s %s %v", c.Tag, c.Op, operand))
+		default:
+			return "", "", fmt.Errorf("can't replay query %q, the condition on %s is not supported", q, c.Tag)
+		}
+	}
+	switch {
+	case event == types.EventTx:
+		return event, strings.Join(others, " AND "), nil
+	case event == types.EventNewBlock && len(others) == 0:
+		return event, "", nil
+	}
+	return "", "", fmt.Errorf("can't replay query %q, only Tx and NewBlock events are", q)
+}
+
+// eventHeight returns the height of a Tx or NewBlock event, 0 for other events.
+func eventHeight(event ctypes.ResultEvent) int64 {
+	switch data := event.Data.(type) {
+	case types.EventDataTx:
+		return data.Height
+	case types.EventDataNewBlock:
+		if data.Block != nil {
+			return data.Block.Height
+		}
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReplayQuery(t *testing.T) {
+	event, search, err := replayQuery("tm.event='Tx' AND transfer.sender='bnb1abc' AND tx.height>10")
+	assert.NoError(t, err)
+	assert.Equal(t, types.EventTx, event)
+	assert.Equal(t, "transfer.sender = 'bnb1abc' AND tx.height > 10", search)
+
+	event, search, err = replayQuery("tm.event='NewBlock'")
+	assert.NoError(t, err)
+	assert.Equal(t, types.EventNewBlock, event)
+	assert.Equal(t, "", search)
+
+	_, _, err = replayQuery("tm.event='NewBlock' AND block.height>10")
+	assert.Error(t, err)
+	_, _, err = replayQuery("tm.event='Vote'")
+	assert.Error(t, err)
+	_, _, err = replayQuery("tm.event='Tx' AND tx.time>=TIME 2019-01-01T00:00:00Z")
+	assert.Error(t, err)
+}
+
+// The node drops the connection after the event at height 5, and is at height 10 once
+// reconnected: the tx at height 8 is replayed, the live one at height 10 is dropped.
+func TestReplayAfterReconnect(t *testing.T) {
+	var subID rpctypes.JSONRPCStringID
+	event := func(c *testConn, height int64) {
+		c.event(subID, ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Height: height, Tx: []byte{1}}}})
+	}
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		switch req.Method {
+		case "subscribe":
+			subID = id
+			conn.reply(id, struct{}{})
+			if conn.n > 1 {
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					event(conn, 10)
+					event(conn, 11)
+				}()
+			}
+		case "status":
+			height := int64(3)
+			if conn.n > 1 {
+				height = 10
+			}
+			conn.reply(id, ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: height}})
+			if conn.n == 1 {
+				event(conn, 5)
+				time.Sleep(100 * time.Millisecond)
+				return false
+			}
+		case "tx_search":
+			var params struct{ Query string }
+			assert.NoError(t, json.Unmarshal(req.Params, &params))
+			assert.Equal(t, "transfer.sender = 'x' AND tx.height>=6 AND tx.height<=10", params.Query)
+			conn.reply(id, ResultTxSearch{Txs: []*ResultTx{{Height: 8, Tx: []byte{2}}}, TotalCount: 1})
+		}
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+	c.SetReplay(true)
+	gaps := make(chan Gap, 1)
+	c.SetGapHandler(func(gap Gap) { gaps <- gap })
+	out, err := c.Subscribe("tm.event='Tx' AND transfer.sender='x'", 10)
+	assert.NoError(t, err)
+
+	var heights []int64
+	timeout := time.After(5 * time.Second)
+	for len(heights) < 3 {
+		select {
+		case event := <-out:
+			heights = append(heights, eventHeight(event))
+		case <-timeout:
+			t.Fatal("missing events, received", heights)
+		}
+	}
+	assert.Equal(t, []int64{5, 8, 11}, heights)
+	gap := <-gaps
+	assert.Equal(t, int64(5), gap.LastHeight)
+	assert.Equal(t, int64(10), gap.Height)
+	assert.True(t, gap.Replayed)
+	assert.NoError(t, gap.Err)
+}
@@ -0,0 +1,163 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	gtypes "github.com/bnb-chain/go-sdk/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+	nodeTypes "github.com/bnb-chain/node/common/types"
+)
+
+// SimulateResult is the outcome of a dry run. The tx is expected to pass CheckTx
+// when Problems is empty.
+type SimulateResult struct {
+	Sender   types.AccAddress
+	Fee      int64
+	Required types.Coins
+	Problems []error
+}
+
+func (r *SimulateResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *SimulateResult) addProblem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Errorf(format, args...))
+}
+
+// Simulate checks msgs the way the chain would before anything is signed: msgs are
+// validated, the tokens they spend must exist, and the sender must hold the amounts
+// plus the fee. The returned error is only set when the node can't be queried, or
+// can't tell whether a token exists.
+func (c *HTTP) Simulate(msgs []msg.Msg, options ...tx.Option) (*SimulateResult, error) {
+	return c.SimulateCtx(context.Background(), msgs, options...)
+}
+
+func (c *HTTP) SimulateCtx(ctx context.Context, msgs []msg.Msg, options ...tx.Option) (*SimulateResult, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msg to simulate")
+	}
+	res := &SimulateResult{Required: types.Coins{}}
+	if c.key != nil {
+		res.Sender = c.key.GetAddr()
+	} else if signers := msgs[0].GetSigners(); len(signers) > 0 {
+		res.Sender = signers[0]
+	} else {
+		return nil, fmt.Errorf("msg %s has no signer", msgs[0].Type())
+	}
+	if len(msgs) > tx.MaxMsgs {
+		res.addProblem("%d msgs in tx, the chain accepts at most %d", len(msgs), tx.MaxMsgs)
+	}
+	signMsg := tx.NewSignMsg(networkChainID(), msgs, options...)
+	if len(signMsg.Memo) > gtypes.MaxMemoLength {
+		res.addProblem("memo is longer than %d characters", gtypes.MaxMemoLength)
+	}
+
+	for i, m := range msgs {
+		if err := m.ValidateBasic(); err != nil {
+			res.addProblem("msg %d (%s) is invalid: %v", i, m.Type(), err)
+		}
+		for _, signer := range m.GetSigners() {
+			if !signer.Equals(res.Sender) {
+				res.addProblem("msg %d (%s) must be signed by %s", i, m.Type(), signer)
+			}
+		}
+		res.Required = res.Required.Plus(spentCoins(m, res.Sender))
+	}
+
+	params, err := c.GetFeeCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Fee, err = tx.CalcFee(params, msgs...); err != nil {
+		res.addProblem("%v", err)
+	}
+	if res.Fee > 0 {
+		res.Required = res.Required.Plus(types.Coins{{Denom: gtypes.NativeSymbol, Amount: res.Fee}})
+	}
+
+	for _, coin := range res.Required {
+		if coin.Denom == gtypes.NativeSymbol {
+			continue
+		}
+		exists, err := c.tokenExists(ctx, coin.Denom)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			res.addProblem("token %s does not exist", coin.Denom)
+		}
+	}
+
+	acc, err := c.GetAccountCtx(ctx, res.Sender)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		res.addProblem("account %s does not exist in the chain", res.Sender)
+		return res, nil
+	}
+	balance := acc.GetCoins()
+	for _, coin := range res.Required {
+		if free := balance.AmountOf(coin.Denom); free < coin.Amount {
+			res.addProblem("insufficient %s: required %d, free %d", coin.Denom, coin.Amount, free)
+		}
+	}
+	return res, nil
+}
+
+// tokenExists reports whether the token or mini token symbol is issued, see existsCC.
+func (c *HTTP) tokenExists(ctx context.Context, symbol string) (bool, error) {
+	if !nodeTypes.IsMiniTokenSymbol(symbol) {
+		return c.existsCC(ctx, symbol)
+	}
+	_, err := c.GetMiniTokenInfoCtx(ctx, symbol)
+	if errors.Is(err, tx.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// spentCoins returns what m takes out of the free balance of sender.
+func spentCoins(m msg.Msg, sender types.AccAddress) types.Coins {
+	coins := types.Coins{}
+	switch m := m.(type) {
+	case msg.SendMsg:
+		for _, input := range m.Inputs {
+			if input.Address.Equals(sender) {
+				coins = coins.Plus(input.Coins)
+			}
+		}
+	case msg.TimeLockMsg:
+		coins = coins.Plus(m.Amount)
+	case msg.HTLTMsg:
+		coins = coins.Plus(m.Amount)
+	case msg.DepositHTLTMsg:
+		coins = coins.Plus(m.Amount)
+	case msg.SubmitProposalMsg:
+		coins = coins.Plus(m.InitialDeposit)
+	case msg.DepositMsg:
+		coins = coins.Plus(m.Amount)
+	case msg.SideChainSubmitProposalMsg:
+		coins = coins.Plus(m.InitialDeposit)
+	case msg.SideChainDepositMsg:
+		coins = coins.Plus(m.Amount)
+	case msg.TokenBurnMsg:
+		coins = coins.Plus(types.Coins{{Denom: m.Symbol, Amount: m.Amount}})
+	case msg.TokenFreezeMsg:
+		coins = coins.Plus(types.Coins{{Denom: m.Symbol, Amount: m.Amount}})
+	case msg.BindMsg:
+		coins = coins.Plus(types.Coins{{Denom: m.Symbol, Amount: m.Amount}})
+	case msg.TransferOutMsg:
+		coins = coins.Plus(types.Coins{m.Amount})
+	case msg.MsgDelegate:
+		coins = coins.Plus(types.Coins{m.Delegation})
+	case msg.SideChainDelegateMsg:
+		coins = coins.Plus(types.Coins{m.Delegation})
+	}
+	return coins.Sort()
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	gtypes "github.com/bnb-chain/go-sdk/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func TestSimulate(t *testing.T) {
+	alice, bob := types.AccAddress("simulate-test-alice-"), types.AccAddress("simulate-test-bob---")
+	cdc := gtypes.NewCodec()
+	fees, _ := cdc.MarshalBinaryLengthPrefixed([]types.FeeParam{
+		&types.TransferFeeParam{
+			FixedFeeParams:    types.FixedFeeParams{MsgType: "send", Fee: 37500, FeeFor: types.FeeForProposer},
+			MultiTransferFee:  30000,
+			LowerLimitAsMulti: 2,
+		},
+	})
+	token, _ := cdc.MarshalBinaryLengthPrefixed(types.Token{Name: "XYZ", Symbol: "XYZ-000", TotalSupply: 1000})
+	account, _ := cdc.MarshalBinaryBare(&types.AppAccount{BaseAccount: types.BaseAccount{
+		Address: alice,
+		Coins:   types.Coins{{Denom: "BNB", Amount: 100000}},
+	}})
+	values := map[string][]byte{
+		fmt.Sprintf("%s/fees", ParamABCIPrefix): fees,
+		"tokens/info/XYZ-000":                   token,
+		"/account/" + alice.String():            account,
+		"/account/" + bob.String():              nil,
+	}
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		var params struct {
+			Path string `json:"path"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Params, &params))
+		resp := abci.ResponseQuery{Code: 1, Log: "unknown query path"}
+		if value, ok := values[params.Path]; ok {
+			resp = abci.ResponseQuery{Value: value}
+		} else if symbol := strings.TrimPrefix(params.Path, "tokens/info/"); symbol != params.Path {
+			resp = abci.ResponseQuery{Code: 65537, Log: fmt.Sprintf("token(%s) not found", symbol)}
+			if symbol == "DOWN-000" {
+				resp = abci.ResponseQuery{Code: 65537, Log: "store is unavailable"}
+			}
+		}
+		conn.reply(req.ID.(rpctypes.JSONRPCStringID), ctypes.ResultABCIQuery{Response: resp})
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+
+	send := func(from types.AccAddress, coins types.Coins) msg.Msg {
+		return msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: bob, Coins: coins}})
+	}
+	res, err := c.Simulate([]msg.Msg{send(alice, types.Coins{{Denom: "BNB", Amount: 100}})})
+	assert.NoError(t, err)
+	assert.True(t, res.OK(), "%v", res.Problems)
+	assert.Equal(t, alice, res.Sender)
+	assert.Equal(t, int64(37500), res.Fee)
+	assert.Equal(t, types.Coins{{Denom: "BNB", Amount: 37600}}, res.Required)
+
+	// too much, of a token not held and of one which does not exist
+	res, err = c.Simulate([]msg.Msg{send(alice, types.Coins{{Denom: "ABC-000", Amount: 1}, {Denom: "BNB", Amount: 100000}, {Denom: "XYZ-000", Amount: 1}})},
+		tx.WithMemo(string(make([]byte, 200))))
+	assert.NoError(t, err)
+	assert.False(t, res.OK())
+	assert.Len(t, res.Problems, 5, "%v", res.Problems)
+
+	res, err = c.Simulate([]msg.Msg{send(bob, types.Coins{{Denom: "BNB", Amount: 100}})})
+	assert.NoError(t, err)
+	assert.Equal(t, []error{fmt.Errorf("account %s does not exist in the chain", bob)}, res.Problems)
+
+	res, err = c.Simulate([]msg.Msg{send(alice, types.Coins{{Denom: "BNB", Amount: 100}}), send(alice, types.Coins{{Denom: "BNB", Amount: 100}})})
+	assert.NoError(t, err)
+	assert.Equal(t, []error{fmt.Errorf("2 msgs in tx, the chain accepts at most 1")}, res.Problems)
+
+	// the node can't tell whether the token exists
+	_, err = c.Simulate([]msg.Msg{send(alice, types.Coins{{Denom: "DOWN-000", Amount: 1}})})
+	assert.True(t, errors.Is(err, tx.ErrInternal), "%v", err)
+
+	_, err = c.Simulate(nil)
+	assert.Error(t, err)
+}
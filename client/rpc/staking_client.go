@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -70,6 +71,31 @@ type StakingClient interface {
 	GetSideChainRedelegationsByValidator(sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error)
 	GetSideChainPool(sideChainId string) (*types.Pool, error)
 	GetSideChainAllValidatorsCount(sideChainId string, jailInvolved bool) (int, error)
+
+	QueryValidatorCtx(ctx context.Context, valAddr types.ValAddress) (*types.Validator, error)
+	QueryTopValidatorsCtx(ctx context.Context, top int) ([]types.Validator, error)
+	QueryDelegationCtx(ctx context.Context, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error)
+	QueryDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.DelegationResponse, error)
+	QueryRedelegationCtx(ctx context.Context, delAddr types.AccAddress, valSrcAddr types.ValAddress, valDstAddr types.ValAddress) (*types.Redelegation, error)
+	QueryRedelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.Redelegation, error)
+	QueryUnbondingDelegationCtx(ctx context.Context, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error)
+	QueryUnbondingDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.UnbondingDelegation, error)
+	GetUnBondingDelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.UnbondingDelegation, error)
+	GetRedelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.Redelegation, error)
+	GetPoolCtx(ctx context.Context) (*types.Pool, error)
+	GetAllValidatorsCountCtx(ctx context.Context, jailInvolved bool) (int, error)
+	QuerySideChainValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) (*types.Validator, error)
+	QuerySideChainTopValidatorsCtx(ctx context.Context, sideChainId string, top int) ([]types.Validator, error)
+	QuerySideChainDelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error)
+	QuerySideChainDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.DelegationResponse, error)
+	QuerySideChainRedelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valSrcAddr types.ValAddress, valDstAddr types.ValAddress) (*types.Redelegation, error)
+	QuerySideChainRedelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.Redelegation, error)
+	QuerySideChainUnbondingDelegationCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error)
+	QuerySideChainUnbondingDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.UnbondingDelegation, error)
+	GetSideChainUnBondingDelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.UnbondingDelegation, error)
+	GetSideChainRedelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error)
+	GetSideChainPoolCtx(ctx context.Context, sideChainId string) (*types.Pool, error)
+	GetSideChainAllValidatorsCountCtx(ctx context.Context, sideChainId string, jailInvolved bool) (int, error)
 }
 
 type bechValidator struct {
@@ -263,6 +289,10 @@ func (c *HTTP) SideChainUnjail(sideChainId string, valAddr types.ValAddress, syn
 
 // Query a validator
 func (c *HTTP) QuerySideChainValidator(sideChainId string, valAddr types.ValAddress) (*types.Validator, error) {
+	return c.QuerySideChainValidatorCtx(context.Background(), sideChainId, valAddr)
+}
+
+func (c *HTTP) QuerySideChainValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) (*types.Validator, error) {
 	params := types.QueryValidatorParams{
 		BaseParams:    types.NewBaseParams(sideChainId),
 		ValidatorAddr: valAddr,
@@ -274,7 +304,7 @@ func (c *HTTP) QuerySideChainValidator(sideChainId string, valAddr types.ValAddr
 		return nil, err
 	}
 
-	res, err := c.QueryWithData("custom/stake/validator", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/validator", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +325,10 @@ func (c *HTTP) QuerySideChainValidator(sideChainId string, valAddr types.ValAddr
 }
 
 func (c *HTTP) QuerySideChainTopValidators(sideChainId string, top int) ([]types.Validator, error) {
+	return c.QuerySideChainTopValidatorsCtx(context.Background(), sideChainId, top)
+}
+
+func (c *HTTP) QuerySideChainTopValidatorsCtx(ctx context.Context, sideChainId string, top int) ([]types.Validator, error) {
 	if top > 50 || top < 1 {
 		return nil, fmt.Errorf("top must be between 1 and 50")
 	}
@@ -310,7 +344,7 @@ func (c *HTTP) QuerySideChainTopValidators(sideChainId string, top int) ([]types
 		return nil, err
 	}
 
-	res, err := c.QueryWithData("custom/stake/topValidators", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/topValidators", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -338,6 +372,10 @@ func (c *HTTP) QuerySideChainTopValidators(sideChainId string, top int) ([]types
 
 // Query a delegation based on address and validator address
 func (c *HTTP) QuerySideChainDelegation(sideChainId string, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
+	return c.QuerySideChainDelegationCtx(context.Background(), sideChainId, delAddr, valAddr)
+}
+
+func (c *HTTP) QuerySideChainDelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
 	params := types.QueryBondsParams{
 		BaseParams:    types.NewBaseParams(sideChainId),
 		DelegatorAddr: delAddr,
@@ -349,7 +387,7 @@ func (c *HTTP) QuerySideChainDelegation(sideChainId string, delAddr types.AccAdd
 		return nil, err
 	}
 
-	response, err := c.QueryWithData("custom/stake/delegation", bz)
+	response, err := c.QueryWithDataCtx(ctx, "custom/stake/delegation", bz)
 	if err != nil {
 		return nil, err
 	} else if len(response) == 0 {
@@ -366,6 +404,10 @@ func (c *HTTP) QuerySideChainDelegation(sideChainId string, delAddr types.AccAdd
 
 // Query all delegations made from one delegator
 func (c *HTTP) QuerySideChainDelegations(sideChainId string, delAddr types.AccAddress) ([]types.DelegationResponse, error) {
+	return c.QuerySideChainDelegationsCtx(context.Background(), sideChainId, delAddr)
+}
+
+func (c *HTTP) QuerySideChainDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.DelegationResponse, error) {
 	params := types.QueryDelegatorParams{
 		BaseParams:    types.NewBaseParams(sideChainId),
 		DelegatorAddr: delAddr,
@@ -379,7 +421,7 @@ func (c *HTTP) QuerySideChainDelegations(sideChainId string, delAddr types.AccAd
 		return delegationResponses, err
 	}
 
-	response, err := c.QueryWithData("custom/stake/delegatorDelegations", bz)
+	response, err := c.QueryWithDataCtx(ctx, "custom/stake/delegatorDelegations", bz)
 	if err != nil {
 		return delegationResponses, err
 	} else if len(response) == 0 {
@@ -396,14 +438,19 @@ func (c *HTTP) QuerySideChainDelegations(sideChainId string, delAddr types.AccAd
 // Query a redelegation record based on delegator and a source and destination validator address
 func (c *HTTP) QuerySideChainRedelegation(sideChainId string, delAddr types.AccAddress, valSrcAddr types.ValAddress,
 	valDstAddr types.ValAddress) (*types.Redelegation, error) {
-	storePrefix, err := c.getSideChainStorePrefixKey(sideChainId)
+	return c.QuerySideChainRedelegationCtx(context.Background(), sideChainId, delAddr, valSrcAddr, valDstAddr)
+}
+
+func (c *HTTP) QuerySideChainRedelegationCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress, valSrcAddr types.ValAddress,
+	valDstAddr types.ValAddress) (*types.Redelegation, error) {
+	storePrefix, err := c.getSideChainStorePrefixKey(ctx, sideChainId)
 	if err != nil {
 		return nil, err
 	}
 
 	redKey := getREDKey(delAddr, valSrcAddr, valDstAddr)
 	key := append(storePrefix, redKey...)
-	res, err := c.QueryStore(key, StakeStoreKey)
+	res, err := c.QueryStoreCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return nil, err
 	}
@@ -422,13 +469,17 @@ func (c *HTTP) QuerySideChainRedelegation(sideChainId string, delAddr types.AccA
 
 // Query all redelegations records for one delegator
 func (c *HTTP) QuerySideChainRedelegations(sideChainId string, delAddr types.AccAddress) ([]types.Redelegation, error) {
-	storePrefix, err := c.getSideChainStorePrefixKey(sideChainId)
+	return c.QuerySideChainRedelegationsCtx(context.Background(), sideChainId, delAddr)
+}
+
+func (c *HTTP) QuerySideChainRedelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.Redelegation, error) {
+	storePrefix, err := c.getSideChainStorePrefixKey(ctx, sideChainId)
 	if err != nil {
 		return nil, err
 	}
 
 	key := append(storePrefix, getREDsKey(delAddr)...)
-	resKVs, err := c.QueryStoreSubspace(key, StakeStoreKey)
+	resKVs, err := c.QueryStoreSubspaceCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return nil, err
 	}
@@ -453,14 +504,18 @@ func (c *HTTP) QuerySideChainRedelegations(sideChainId string, delAddr types.Acc
 
 // Query an unbonding-delegation record based on delegator and validator address
 func (c *HTTP) QuerySideChainUnbondingDelegation(sideChainId string, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
-	storePrefix, err := c.getSideChainStorePrefixKey(sideChainId)
+	return c.QuerySideChainUnbondingDelegationCtx(context.Background(), sideChainId, valAddr, delAddr)
+}
+
+func (c *HTTP) QuerySideChainUnbondingDelegationCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
+	storePrefix, err := c.getSideChainStorePrefixKey(ctx, sideChainId)
 	if err != nil {
 		return nil, err
 	}
 
 	ubdKey := getUBDKey(delAddr, valAddr)
 	key := append(storePrefix, ubdKey...)
-	res, err := c.QueryStore(key, StakeStoreKey)
+	res, err := c.QueryStoreCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return nil, err
 	}
@@ -480,14 +535,18 @@ func (c *HTTP) QuerySideChainUnbondingDelegation(sideChainId string, valAddr typ
 
 // Query all unbonding-delegations records for one delegator
 func (c *HTTP) QuerySideChainUnbondingDelegations(sideChainId string, delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
-	storePrefix, err := c.getSideChainStorePrefixKey(sideChainId)
+	return c.QuerySideChainUnbondingDelegationsCtx(context.Background(), sideChainId, delAddr)
+}
+
+func (c *HTTP) QuerySideChainUnbondingDelegationsCtx(ctx context.Context, sideChainId string, delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	storePrefix, err := c.getSideChainStorePrefixKey(ctx, sideChainId)
 	if err != nil {
 		return nil, err
 	}
 
 	key := append(storePrefix, getUBDsKey(delAddr)...)
 
-	resKVs, err := c.QueryStoreSubspace(key, StakeStoreKey)
+	resKVs, err := c.QueryStoreSubspaceCtx(ctx, key, StakeStoreKey)
 	if err != nil {
 		return nil, err
 	}
@@ -511,6 +570,10 @@ func (c *HTTP) QuerySideChainUnbondingDelegations(sideChainId string, delAddr ty
 }
 
 func (c *HTTP) GetSideChainUnBondingDelegationsByValidator(sideChainId string, valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
+	return c.GetSideChainUnBondingDelegationsByValidatorCtx(context.Background(), sideChainId, valAddr)
+}
+
+func (c *HTTP) GetSideChainUnBondingDelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
 	params := types.QueryValidatorParams{
 		BaseParams:    types.NewBaseParams(sideChainId),
 		ValidatorAddr: valAddr,
@@ -521,7 +584,7 @@ func (c *HTTP) GetSideChainUnBondingDelegationsByValidator(sideChainId string, v
 		return nil, err
 	}
 
-	response, err := c.QueryWithData("custom/stake/validatorUnbondingDelegations", bz)
+	response, err := c.QueryWithDataCtx(ctx, "custom/stake/validatorUnbondingDelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -540,6 +603,10 @@ func (c *HTTP) GetSideChainUnBondingDelegationsByValidator(sideChainId string, v
 }
 
 func (c *HTTP) GetSideChainRedelegationsByValidator(sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error) {
+	return c.GetSideChainRedelegationsByValidatorCtx(context.Background(), sideChainId, valAddr)
+}
+
+func (c *HTTP) GetSideChainRedelegationsByValidatorCtx(ctx context.Context, sideChainId string, valAddr types.ValAddress) ([]types.Redelegation, error) {
 	params := types.QueryValidatorParams{
 		BaseParams:    types.NewBaseParams(sideChainId),
 		ValidatorAddr: valAddr,
@@ -549,7 +616,7 @@ func (c *HTTP) GetSideChainRedelegationsByValidator(sideChainId string, valAddr
 	if err != nil {
 		return nil, err
 	}
-	response, err := c.QueryWithData("custom/stake/validatorRedelegations", bz)
+	response, err := c.QueryWithDataCtx(ctx, "custom/stake/validatorRedelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -568,14 +635,18 @@ func (c *HTTP) GetSideChainRedelegationsByValidator(sideChainId string, valAddr
 }
 
 func (c *HTTP) GetSideChainPool(sideChainId string) (*types.Pool, error) {
-	storePrefix, err := c.getSideChainStorePrefixKey(sideChainId)
+	return c.GetSideChainPoolCtx(context.Background(), sideChainId)
+}
+
+func (c *HTTP) GetSideChainPoolCtx(ctx context.Context, sideChainId string) (*types.Pool, error) {
+	storePrefix, err := c.getSideChainStorePrefixKey(ctx, sideChainId)
 
 	if err != nil {
 		return nil, err
 	}
 
 	key := append(storePrefix, PoolKey...)
-	res, err := c.QueryStore(key, StakeStoreKey)
+	res, err := c.QueryStoreCtx(ctx, key, StakeStoreKey)
 
 	if len(res) == 0 {
 		zeroDec, err := types.NewDecFromStr("0")
@@ -598,6 +669,10 @@ func (c *HTTP) GetSideChainPool(sideChainId string) (*types.Pool, error) {
 }
 
 func (c *HTTP) GetSideChainAllValidatorsCount(sideChainId string, jailInvolved bool) (int, error) {
+	return c.GetSideChainAllValidatorsCountCtx(context.Background(), sideChainId, jailInvolved)
+}
+
+func (c *HTTP) GetSideChainAllValidatorsCountCtx(ctx context.Context, sideChainId string, jailInvolved bool) (int, error) {
 	params := types.NewBaseParams(sideChainId)
 
 	bz, err := json.Marshal(params)
@@ -609,7 +684,7 @@ func (c *HTTP) GetSideChainAllValidatorsCount(sideChainId string, jailInvolved b
 	if jailInvolved {
 		path = "custom/stake/allValidatorsCount"
 	}
-	response, err := c.QueryWithData(path, bz)
+	response, err := c.QueryWithDataCtx(ctx, path, bz)
 
 	if err != nil {
 		return 0, err
@@ -620,9 +695,9 @@ func (c *HTTP) GetSideChainAllValidatorsCount(sideChainId string, jailInvolved b
 	return strconv.Atoi(count)
 }
 
-func (c *HTTP) getSideChainStorePrefixKey(sideChainId string) ([]byte, error) {
+func (c *HTTP) getSideChainStorePrefixKey(ctx context.Context, sideChainId string) ([]byte, error) {
 	key := append(SideChainStorePrefixByIdKey, []byte(sideChainId)...)
-	result, err := c.QueryStore(key, StakeScStoreKey)
+	result, err := c.QueryStoreCtx(ctx, key, StakeScStoreKey)
 
 	if err != nil {
 		return nil, err
@@ -829,6 +904,10 @@ func (c *HTTP) SideChainStakeMigration(
 }
 
 func (c *HTTP) QueryValidator(valAddr types.ValAddress) (*types.Validator, error) {
+	return c.QueryValidatorCtx(context.Background(), valAddr)
+}
+
+func (c *HTTP) QueryValidatorCtx(ctx context.Context, valAddr types.ValAddress) (*types.Validator, error) {
 	params := types.QueryValidatorParams{
 		ValidatorAddr: valAddr,
 	}
@@ -839,7 +918,7 @@ func (c *HTTP) QueryValidator(valAddr types.ValAddress) (*types.Validator, error
 		return nil, err
 	}
 
-	res, err := c.QueryWithData("custom/stake/validator", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/validator", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -860,6 +939,10 @@ func (c *HTTP) QueryValidator(valAddr types.ValAddress) (*types.Validator, error
 }
 
 func (c *HTTP) QueryTopValidators(top int) ([]types.Validator, error) {
+	return c.QueryTopValidatorsCtx(context.Background(), top)
+}
+
+func (c *HTTP) QueryTopValidatorsCtx(ctx context.Context, top int) ([]types.Validator, error) {
 	if top > 50 || top < 1 {
 		return nil, fmt.Errorf("top must be between 1 and 50")
 	}
@@ -874,7 +957,7 @@ func (c *HTTP) QueryTopValidators(top int) ([]types.Validator, error) {
 		return nil, err
 	}
 
-	res, err := c.QueryWithData("custom/stake/topValidators", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/topValidators", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -902,14 +985,27 @@ func (c *HTTP) QueryTopValidators(top int) ([]types.Validator, error) {
 }
 
 func (c *HTTP) QueryDelegation(delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
-	return c.QuerySideChainDelegation("", delAddr, valAddr)
+	return c.QueryDelegationCtx(context.Background(), delAddr, valAddr)
+}
+
+func (c *HTTP) QueryDelegationCtx(ctx context.Context, delAddr types.AccAddress, valAddr types.ValAddress) (*types.DelegationResponse, error) {
+	return c.QuerySideChainDelegationCtx(ctx, "", delAddr, valAddr)
 }
 
 func (c *HTTP) QueryDelegations(delAddr types.AccAddress) ([]types.DelegationResponse, error) {
-	return c.QuerySideChainDelegations("", delAddr)
+	return c.QueryDelegationsCtx(context.Background(), delAddr)
+}
+
+func (c *HTTP) QueryDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.DelegationResponse, error) {
+	return c.QuerySideChainDelegationsCtx(ctx, "", delAddr)
 }
 
 func (c *HTTP) QueryRedelegation(delAddr types.AccAddress, valSrcAddr types.ValAddress,
+	valDstAddr types.ValAddress) (*types.Redelegation, error) {
+	return c.QueryRedelegationCtx(context.Background(), delAddr, valSrcAddr, valDstAddr)
+}
+
+func (c *HTTP) QueryRedelegationCtx(ctx context.Context, delAddr types.AccAddress, valSrcAddr types.ValAddress,
 	valDstAddr types.ValAddress) (*types.Redelegation, error) {
 	params := types.QueryRedelegationParams{
 		DelegatorAddr: delAddr,
@@ -920,7 +1016,7 @@ func (c *HTTP) QueryRedelegation(delAddr types.AccAddress, valSrcAddr types.ValA
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/redelegation", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/redelegation", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -931,6 +1027,10 @@ func (c *HTTP) QueryRedelegation(delAddr types.AccAddress, valSrcAddr types.ValA
 
 // Query all redelegations records for one delegator
 func (c *HTTP) QueryRedelegations(delAddr types.AccAddress) ([]types.Redelegation, error) {
+	return c.QueryRedelegationsCtx(context.Background(), delAddr)
+}
+
+func (c *HTTP) QueryRedelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.Redelegation, error) {
 	params := types.QueryDelegatorParams{
 		DelegatorAddr: delAddr,
 	}
@@ -938,7 +1038,7 @@ func (c *HTTP) QueryRedelegations(delAddr types.AccAddress) ([]types.Redelegatio
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/delegatorRedelegations", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/delegatorRedelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -949,6 +1049,10 @@ func (c *HTTP) QueryRedelegations(delAddr types.AccAddress) ([]types.Redelegatio
 
 // Query an unbonding-delegation record based on delegator and validator address
 func (c *HTTP) QueryUnbondingDelegation(valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
+	return c.QueryUnbondingDelegationCtx(context.Background(), valAddr, delAddr)
+}
+
+func (c *HTTP) QueryUnbondingDelegationCtx(ctx context.Context, valAddr types.ValAddress, delAddr types.AccAddress) (*types.UnbondingDelegation, error) {
 	params := types.QueryBondsParams{
 		DelegatorAddr: delAddr,
 		ValidatorAddr: valAddr,
@@ -957,7 +1061,7 @@ func (c *HTTP) QueryUnbondingDelegation(valAddr types.ValAddress, delAddr types.
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/unbondingDelegation", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/unbondingDelegation", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -968,6 +1072,10 @@ func (c *HTTP) QueryUnbondingDelegation(valAddr types.ValAddress, delAddr types.
 
 // Query all unbonding-delegations records for one delegator
 func (c *HTTP) QueryUnbondingDelegations(delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
+	return c.QueryUnbondingDelegationsCtx(context.Background(), delAddr)
+}
+
+func (c *HTTP) QueryUnbondingDelegationsCtx(ctx context.Context, delAddr types.AccAddress) ([]types.UnbondingDelegation, error) {
 	params := types.QueryDelegatorParams{
 		DelegatorAddr: delAddr,
 	}
@@ -975,7 +1083,7 @@ func (c *HTTP) QueryUnbondingDelegations(delAddr types.AccAddress) ([]types.Unbo
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/delegatorUnbondingDelegations", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/delegatorUnbondingDelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -985,6 +1093,10 @@ func (c *HTTP) QueryUnbondingDelegations(delAddr types.AccAddress) ([]types.Unbo
 }
 
 func (c *HTTP) GetUnBondingDelegationsByValidator(valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
+	return c.GetUnBondingDelegationsByValidatorCtx(context.Background(), valAddr)
+}
+
+func (c *HTTP) GetUnBondingDelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.UnbondingDelegation, error) {
 	params := types.QueryValidatorParams{
 		ValidatorAddr: valAddr,
 	}
@@ -992,7 +1104,7 @@ func (c *HTTP) GetUnBondingDelegationsByValidator(valAddr types.ValAddress) ([]t
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/validatorUnbondingDelegations", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/validatorUnbondingDelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -1002,6 +1114,10 @@ func (c *HTTP) GetUnBondingDelegationsByValidator(valAddr types.ValAddress) ([]t
 }
 
 func (c *HTTP) GetRedelegationsByValidator(valAddr types.ValAddress) ([]types.Redelegation, error) {
+	return c.GetRedelegationsByValidatorCtx(context.Background(), valAddr)
+}
+
+func (c *HTTP) GetRedelegationsByValidatorCtx(ctx context.Context, valAddr types.ValAddress) ([]types.Redelegation, error) {
 	params := types.QueryValidatorParams{
 		ValidatorAddr: valAddr,
 	}
@@ -1009,7 +1125,7 @@ func (c *HTTP) GetRedelegationsByValidator(valAddr types.ValAddress) ([]types.Re
 	if err != nil {
 		return nil, err
 	}
-	res, err := c.QueryWithData("custom/stake/validatorRedelegations", bz)
+	res, err := c.QueryWithDataCtx(ctx, "custom/stake/validatorRedelegations", bz)
 	if err != nil {
 		return nil, err
 	}
@@ -1019,6 +1135,10 @@ func (c *HTTP) GetRedelegationsByValidator(valAddr types.ValAddress) ([]types.Re
 }
 
 func (c *HTTP) GetPool() (*types.Pool, error) {
+	return c.GetPoolCtx(context.Background())
+}
+
+func (c *HTTP) GetPoolCtx(ctx context.Context) (*types.Pool, error) {
 	params := types.NewBaseParams("")
 
 	bz, err := json.Marshal(params)
@@ -1026,7 +1146,7 @@ func (c *HTTP) GetPool() (*types.Pool, error) {
 		return nil, err
 	}
 	path := "custom/stake/pool"
-	response, err := c.QueryWithData(path, bz)
+	response, err := c.QueryWithDataCtx(ctx, path, bz)
 
 	if err != nil {
 		return nil, err
@@ -1037,6 +1157,10 @@ func (c *HTTP) GetPool() (*types.Pool, error) {
 }
 
 func (c *HTTP) GetAllValidatorsCount(jailInvolved bool) (int, error) {
+	return c.GetAllValidatorsCountCtx(context.Background(), jailInvolved)
+}
+
+func (c *HTTP) GetAllValidatorsCountCtx(ctx context.Context, jailInvolved bool) (int, error) {
 	params := types.NewBaseParams("")
 
 	bz, err := json.Marshal(params)
@@ -1048,7 +1172,7 @@ func (c *HTTP) GetAllValidatorsCount(jailInvolved bool) (int, error) {
 	if jailInvolved {
 		path = "custom/stake/allValidatorsCount"
 	}
-	response, err := c.QueryWithData(path, bz)
+	response, err := c.QueryWithDataCtx(ctx, path, bz)
 
 	if err != nil {
 		return 0, err
@@ -0,0 +1,436 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+const defaultSubscribeCapacity = 100
+
+// Overflow is what to do with an event when the channel of a subscription is full.
+type Overflow int
+
+const (
+	// OverflowDropNewest drops the event that doesn't fit.
+	OverflowDropNewest Overflow = iota
+	// OverflowDropOldest drops the oldest event not read yet to make room.
+	OverflowDropOldest
+	// OverflowBlock keeps every event: those that don't fit are queued in memory until
+	// the channel is read, so it must be read continuously.
+	OverflowBlock
+)
+
+type SubscribeOptions struct {
+	// Capacity of the channel, 100 by default.
+	Capacity int
+	Overflow Overflow
+}
+
+func (o SubscribeOptions) capacity() int {
+	if o.Capacity > 0 {
+		return o.Capacity
+	}
+	return defaultSubscribeCapacity
+}
+
+// TxEvent is a tx included in a block, successful or not.
+type TxEvent struct {
+	Info
+	Index uint32
+	// Msgs are the msgs of the tx the subscription is about.
+	Msgs []msg.Msg
+}
+
+// TransferEvent is a tx sending coins from or to the subscribed address.
+type TransferEvent struct {
+	TxEvent
+	Transfers []msg.SendMsg
+}
+
+// SubscribeTransfers delivers the txs sending coins from or to addr, until ctx is done.
+// The channel is closed then.
+func (w *WSEvents) SubscribeTransfers(ctx context.Context, addr ntypes.AccAddress, opts SubscribeOptions) (<-chan TransferEvent, error) {
+	events, err := w.joinFeed(ctx, types.EventQueryTx.String(), opts)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan TransferEvent)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				e, ok := w.decodeTxEvent(event, func(m msg.Msg) bool {
+					send, ok := m.(msg.SendMsg)
+					return ok && isTransferOf(send, addr)
+				})
+				if !ok {
+					continue
+				}
+				transfer := TransferEvent{TxEvent: e}
+				for _, m := range e.Msgs {
+					transfer.Transfers = append(transfer.Transfers, m.(msg.SendMsg))
+				}
+				select {
+				case out <- transfer:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeTxsByMsgType delivers the txs with msgs of msgType, e.g. "send" or "orderNew",
+// until ctx is done. The channel is closed then.
+func (w *WSEvents) SubscribeTxsByMsgType(ctx context.Context, msgType string, opts SubscribeOptions) (<-chan TxEvent, error) {
+	return w.subscribeTxs(ctx, opts, func(m msg.Msg) bool {
+		return m.Type() == msgType
+	})
+}
+
+// SubscribeAccountEvents delivers the txs with msgs involving addr, as signer, sender,
+// recipient or any other party, until ctx is done. The channel is closed then.
+func (w *WSEvents) SubscribeAccountEvents(ctx context.Context, addr ntypes.AccAddress, opts SubscribeOptions) (<-chan TxEvent, error) {
+	return w.subscribeTxs(ctx, opts, func(m msg.Msg) bool {
+		for _, involved := range m.GetInvolvedAddresses() {
+			if bytes.Equal(involved, addr) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SubscribeNewBlocks delivers the committed blocks until ctx is done. The channel is
+// closed then.
+func (w *WSEvents) SubscribeNewBlocks(ctx context.Context, opts SubscribeOptions) (<-chan types.EventDataNewBlock, error) {
+	events, err := w.joinFeed(ctx, types.EventQueryNewBlock.String(), opts)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan types.EventDataNewBlock)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				block, ok := event.Data.(types.EventDataNewBlock)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- block:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (w *WSEvents) subscribeTxs(ctx context.Context, opts SubscribeOptions, match func(msg.Msg) bool) (<-chan TxEvent, error) {
+	events, err := w.joinFeed(ctx, types.EventQueryTx.String(), opts)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan TxEvent)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				e, ok := w.decodeTxEvent(event, match)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// decodeTxEvent decodes the tx of event, keeping the msgs matching match. It returns
+// false if none does.
+func (w *WSEvents) decodeTxEvent(event ctypes.ResultEvent, match func(msg.Msg) bool) (TxEvent, bool) {
+	data, ok := event.Data.(types.EventDataTx)
+	if !ok {
+		return TxEvent{}, false
+	}
+	parsed, err := ParseTx(w.cdc, data.Tx)
+	if err != nil {
+		w.Logger.Error("failed to decode tx of event", "height", data.Height, "err", err)
+		return TxEvent{}, false
+	}
+	r := data.Result
+	e := TxEvent{
+		Info: Info{
+			Hash:   data.Tx.Hash(),
+			Height: data.Height,
+			Tx:     parsed,
+			Result: ResponseDeliverTx{
+				Code:      r.Code,
+				Data:      r.Data,
+				Log:       r.Log,
+				Info:      r.Info,
+				GasWanted: r.GasWanted,
+				GasUsed:   r.GasUsed,
+				Events:    r.Events,
+				Codespace: r.Codespace,
+			},
+		},
+		Index: data.Index,
+	}
+	e.complement()
+	for _, m := range parsed.GetMsgs() {
+		if match(m) {
+			e.Msgs = append(e.Msgs, m)
+		}
+	}
+	return e, len(e.Msgs) > 0
+}
+
+func isTransferOf(send msg.SendMsg, addr ntypes.AccAddress) bool {
+	for _, in := range send.Inputs {
+		if bytes.Equal(in.Address, addr) {
+			return true
+		}
+	}
+	for _, out := range send.Outputs {
+		if bytes.Equal(out.Address, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventQueue holds the events of an OverflowBlock subscription until they are read, so
+// that the goroutine handing them, such as the listener of the websocket, never waits.
+type eventQueue struct {
+	mtx    sync.Mutex
+	events []interface{}
+	ready  chan struct{}
+}
+
+func newEventQueue() *eventQueue {
+	return &eventQueue{ready: make(chan struct{}, 1)}
+}
+
+func (q *eventQueue) push(event interface{}) {
+	q.mtx.Lock()
+	q.events = append(q.events, event)
+	q.mtx.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// relay hands the queued events to send in order, until quit is closed or send
+// returns false.
+func (q *eventQueue) relay(quit <-chan struct{}, send func(event interface{}) bool) {
+	for {
+		select {
+		case <-quit:
+			return
+		case <-q.ready:
+		}
+		q.mtx.Lock()
+		events := q.events
+		q.events = nil
+		q.mtx.Unlock()
+		for _, event := range events {
+			if !send(event) {
+				return
+			}
+		}
+	}
+}
+
+// relayEvents moves the queued events of an OverflowBlock subscription to sub.in.
+func (w *WSEvents) relayEvents(sub *subscription) {
+	sub.queue.relay(sub.quit, func(event interface{}) bool {
+		select {
+		case sub.in <- event.(rpctypes.RPCResponse):
+			return true
+		case <-sub.quit:
+		case <-w.Quit():
+		}
+		return false
+	})
+}
+
+// pushEvent hands resp to sub according to its overflow policy, without blocking.
+func (w *WSEvents) pushEvent(sub *subscription, resp rpctypes.RPCResponse) {
+	switch sub.overflow {
+	case OverflowBlock:
+		sub.queue.push(resp)
+	case OverflowDropOldest:
+		for {
+			select {
+			case sub.in <- resp:
+				return
+			default:
+			}
+			select {
+			case <-sub.in:
+				w.Logger.Error("out channel is full, dropped the oldest event", "query", sub.query)
+			default:
+			}
+		}
+	default:
+		select {
+		case sub.in <- resp:
+		default:
+			w.Logger.Error("wanted to publish response, but out channel is full", "result", resp.Result)
+		}
+	}
+}
+
+// feed shares one subscription between the typed subscriptions on its query, as a
+// query can only be subscribed to once.
+type feed struct {
+	query       string
+	events      chan ctypes.ResultEvent
+	quit        chan struct{}
+	subscribers map[*feedSubscriber]struct{}
+}
+
+type feedSubscriber struct {
+	events   chan ctypes.ResultEvent
+	overflow Overflow
+	// queue holds the events not read yet with OverflowBlock
+	queue *eventQueue
+	done  <-chan struct{}
+}
+
+// joinFeed returns the events of query until ctx is done.
+func (w *WSEvents) joinFeed(ctx context.Context, query string, opts SubscribeOptions) (<-chan ctypes.ResultEvent, error) {
+	w.feedsMtx.Lock()
+	defer w.feedsMtx.Unlock()
+	f, ok := w.feeds[query]
+	if !ok {
+		// the subscribers apply their own overflow policy
+		events, err := w.SubscribeWithOptions(query, SubscribeOptions{Overflow: OverflowBlock})
+		if err != nil {
+			return nil, err
+		}
+		f = &feed{
+			query:       query,
+			events:      events,
+			quit:        make(chan struct{}),
+			subscribers: make(map[*feedSubscriber]struct{}),
+		}
+		w.feeds[query] = f
+		go w.runFeed(f)
+	}
+	s := &feedSubscriber{
+		events:   make(chan ctypes.ResultEvent, opts.capacity()),
+		overflow: opts.Overflow,
+		done:     ctx.Done(),
+	}
+	if s.overflow == OverflowBlock {
+		// a slow subscriber must not hold the others
+		s.queue = newEventQueue()
+		go s.queue.relay(s.done, func(event interface{}) bool {
+			select {
+			case s.events <- event.(ctypes.ResultEvent):
+				return true
+			case <-s.done:
+				return false
+			}
+		})
+	}
+	f.subscribers[s] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		w.leaveFeed(f, s)
+	}()
+	return s.events, nil
+}
+
+// leaveFeed unsubscribes from the query of f after its last subscriber left.
+func (w *WSEvents) leaveFeed(f *feed, s *feedSubscriber) {
+	w.feedsMtx.Lock()
+	defer w.feedsMtx.Unlock()
+	delete(f.subscribers, s)
+	// f may have been closed by UnsubscribeAll
+	if len(f.subscribers) > 0 || w.feeds[f.query] != f {
+		return
+	}
+	if err := w.Unsubscribe(f.query); err != nil {
+		// kept for the next subscribers, as the query is still subscribed to
+		w.Logger.Error("failed to unsubscribe", "query", f.query, "err", err)
+		return
+	}
+	close(f.quit)
+	delete(w.feeds, f.query)
+}
+
+func (w *WSEvents) runFeed(f *feed) {
+	for {
+		select {
+		case <-f.quit:
+			return
+		case event := <-f.events:
+			w.feedsMtx.Lock()
+			subscribers := make([]*feedSubscriber, 0, len(f.subscribers))
+			for s := range f.subscribers {
+				subscribers = append(subscribers, s)
+			}
+			w.feedsMtx.Unlock()
+			for _, s := range subscribers {
+				w.pushFeed(f, s, event)
+			}
+		}
+	}
+}
+
+func (w *WSEvents) pushFeed(f *feed, s *feedSubscriber, event ctypes.ResultEvent) {
+	switch s.overflow {
+	case OverflowBlock:
+		s.queue.push(event)
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.events <- event:
+				return
+			case <-s.done:
+				return
+			default:
+			}
+			select {
+			case <-s.events:
+				w.Logger.Error("out channel is full, dropped the oldest event", "query", f.query)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.events <- event:
+		default:
+			w.Logger.Error("out channel is full, dropped the event", "query", f.query)
+		}
+	}
+}
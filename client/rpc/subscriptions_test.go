@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+func TestTypedSubscriptions(t *testing.T) {
+	alice, bob, carol := ntypes.AccAddress("alice"), ntypes.AccAddress("bob"), ntypes.AccAddress("carol")
+	coins := ntypes.Coins{{Denom: "BNB", Amount: 1}}
+	send := func(from, to ntypes.AccAddress) []byte {
+		bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: []msg.Msg{msg.SendMsg{
+			Inputs:  []msg.Input{msg.NewInput(from, coins)},
+			Outputs: []msg.Output{msg.NewOutput(to, coins)},
+		}}})
+		assert.NoError(t, err)
+		return bz
+	}
+
+	var subscribes, unsubscribes int32
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		switch req.Method {
+		case "subscribe":
+			atomic.AddInt32(&subscribes, 1)
+			conn.reply(id, struct{}{})
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				for i, bz := range [][]byte{send(alice, bob), send(bob, carol)} {
+					conn.event(id, ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Height: int64(i + 1), Tx: bz}}})
+				}
+			}()
+		case "unsubscribe":
+			atomic.AddInt32(&unsubscribes, 1)
+			conn.reply(id, struct{}{})
+		}
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transfers, err := c.SubscribeTransfers(ctx, alice, SubscribeOptions{})
+	assert.NoError(t, err)
+	sends, err := c.SubscribeTxsByMsgType(ctx, "send", SubscribeOptions{Overflow: OverflowBlock})
+	assert.NoError(t, err)
+
+	transfer := <-transfers
+	assert.Equal(t, int64(1), transfer.Height)
+	assert.Equal(t, alice, transfer.Transfers[0].Inputs[0].Address)
+	assert.Equal(t, bob, transfer.Transfers[0].Outputs[0].Address)
+	assert.Equal(t, int64(1), (<-sends).Height)
+	assert.Equal(t, int64(2), (<-sends).Height)
+
+	cancel()
+	_, ok := <-transfers
+	assert.False(t, ok)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscribes))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&unsubscribes))
+}
+
+func TestOverflowBlockDoesNotStall(t *testing.T) {
+	const events = 300
+	coins := ntypes.Coins{{Denom: "BNB", Amount: 1}}
+	bz, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Msgs: []msg.Msg{msg.SendMsg{
+		Inputs:  []msg.Input{msg.NewInput(ntypes.AccAddress("alice"), coins)},
+		Outputs: []msg.Output{msg.NewOutput(ntypes.AccAddress("bob"), coins)},
+	}}})
+	assert.NoError(t, err)
+	sent := make(chan struct{})
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		switch req.Method {
+		case "subscribe":
+			conn.reply(id, struct{}{})
+			go func() {
+				for i := 1; i <= events; i++ {
+					conn.event(id, ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Height: int64(i), Tx: bz}}})
+				}
+				close(sent)
+			}()
+		case "status":
+			conn.reply(id, ctypes.ResultStatus{})
+		}
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sends, err := c.SubscribeTxsByMsgType(ctx, "send", SubscribeOptions{Capacity: 1, Overflow: OverflowBlock})
+	assert.NoError(t, err)
+	<-sent
+	// the events are not read, the other calls are still answered
+	statusCtx, statusCancel := context.WithTimeout(context.Background(), time.Second)
+	defer statusCancel()
+	_, err = c.StatusCtx(statusCtx)
+	assert.NoError(t, err)
+	for i := 1; i <= events; i++ {
+		select {
+		case e := <-sends:
+			assert.Equal(t, int64(i), e.Height)
+		case <-time.After(time.Second):
+			t.Fatalf("event %d is lost", i)
+		}
+	}
+}
+
+func TestFeedOverflow(t *testing.T) {
+	w := newWSEvents(nil, "", "", nil)
+	f := &feed{query: "q"}
+	received := func(s *feedSubscriber) []int64 {
+		var heights []int64
+		for len(s.events) > 0 {
+			heights = append(heights, eventHeight(<-s.events))
+		}
+		return heights
+	}
+	newest := &feedSubscriber{events: make(chan ctypes.ResultEvent, 2), overflow: OverflowDropNewest}
+	oldest := &feedSubscriber{events: make(chan ctypes.ResultEvent, 2), overflow: OverflowDropOldest}
+	for height := int64(1); height <= 3; height++ {
+		event := ctypes.ResultEvent{Data: types.EventDataTx{TxResult: types.TxResult{Height: height}}}
+		w.pushFeed(f, newest, event)
+		w.pushFeed(f, oldest, event)
+	}
+	assert.Equal(t, []int64{1, 2}, received(newest))
+	assert.Equal(t, []int64{2, 3}, received(oldest))
+}
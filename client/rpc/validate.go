@@ -39,6 +39,7 @@ var (
 	DepthLevelExceedRangeError        = fmt.Errorf("the level is out of range [%d, %d]", 0, maxDepthLevel)
 	KeyMissingError                   = fmt.Errorf("keymanager is missing, use SetKeyManager to set key")
 	EmptyResultError				  = fmt.Errorf("Empty result ")
+	ZeroRecordsError                  = fmt.Errorf("zero records")
 )
 
 func ValidateABCIPath(path string) error {
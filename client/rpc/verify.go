@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+var (
+	ProofMissingError          = fmt.Errorf("the node returned no proof")
+	SubspaceNotVerifiableError = fmt.Errorf("subspace queries can't be verified, they have no proof")
+)
+
+var proofRuntime = store.DefaultProofRuntime()
+
+// Verifier checks the headers of a chain against a trusted validator set. A header
+// signed by another set is trusted when more than 2/3 of the trusted set signed it
+// too, and its set becomes the trusted one. It is safe for concurrent use.
+type Verifier struct {
+	chainID string
+
+	mtx        sync.Mutex
+	height     int64
+	validators *types.ValidatorSet
+}
+
+// NewVerifier trusts validators as the validator set of chainID at height, e.g. taken
+// from the genesis file or from a node of the caller.
+func NewVerifier(chainID string, height int64, validators *types.ValidatorSet) *Verifier {
+	return &Verifier{chainID: chainID, height: height, validators: validators}
+}
+
+// Verify checks that header was committed by more than 2/3 of validators, the
+// validator set of its height, and that validators can be trusted.
+func (v *Verifier) Verify(header types.SignedHeader, validators *types.ValidatorSet) error {
+	if err := header.ValidateBasic(v.chainID); err != nil {
+		return err
+	}
+	if !bytes.Equal(validators.Hash(), header.ValidatorsHash) {
+		return fmt.Errorf("the validators don't match the validators hash of header %d", header.Height)
+	}
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if bytes.Equal(validators.Hash(), v.validators.Hash()) {
+		if err := validators.VerifyCommit(v.chainID, header.Commit.BlockID, header.Height, header.Commit); err != nil {
+			return err
+		}
+	} else {
+		if header.Height <= v.height {
+			return fmt.Errorf("header %d is signed by another validator set than the one trusted at height %d", header.Height, v.height)
+		}
+		err := v.validators.VerifyFutureCommit(validators, v.chainID, header.Commit.BlockID, header.Height, header.Commit)
+		if err != nil {
+			return fmt.Errorf("the validator set changed too much since height %d: %v", v.height, err)
+		}
+		v.validators = validators
+	}
+	if header.Height > v.height {
+		v.height = header.Height
+	}
+	return nil
+}
+
+// SetVerifier makes the store queries, QueryStore and the queries using it such as
+// GetCommitAccount, verify the proofs returned by the node against headers checked by v,
+// so that untrusted nodes can be used. QueryStoreSubspace fails then. The account in the
+// cache of the node has no proof, so GetAccount, GetBalance and GetBalances return the
+// committed account like GetCommitAccount.
+func (c *HTTP) SetVerifier(v *Verifier) {
+	c.verifier = v
+}
+
+// VerifiedCommit returns the header at height once checked by the verifier.
+func (c *HTTP) VerifiedCommit(height int64) (*types.SignedHeader, error) {
+	return c.VerifiedCommitCtx(context.Background(), height)
+}
+
+func (c *HTTP) VerifiedCommitCtx(ctx context.Context, height int64) (*types.SignedHeader, error) {
+	if c.verifier == nil {
+		return nil, fmt.Errorf("no verifier, use SetVerifier to set one")
+	}
+	commit, err := c.CommitCtx(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := c.ValidatorsCtx(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifier.Verify(commit.SignedHeader, types.NewValidatorSet(validators.Validators)); err != nil {
+		return nil, err
+	}
+	return &commit.SignedHeader, nil
+}
+
+// queryStoreVerified queries key in storeName and checks the proof of the value, or of
+// its absence, against the app hash of the verified header.
+func (c *HTTP) queryStoreVerified(ctx context.Context, key cmn.HexBytes, storeName string) ([]byte, error) {
+	status, err := c.StatusCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the app hash of a state is in the header of the next block
+	height := status.SyncInfo.LatestBlockHeight - 1
+	if height < 1 {
+		return nil, fmt.Errorf("no block to verify the query against yet")
+	}
+	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
+	result, err := c.ABCIQueryWithOptionsCtx(ctx, path, key, client.ABCIQueryOptions{Height: height, Prove: true})
+	if err != nil {
+		return nil, err
+	}
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, tx.NewABCIError(resp.Code, resp.Log)
+	}
+	if resp.Height != height {
+		return nil, fmt.Errorf("the node answered at height %d instead of %d", resp.Height, height)
+	}
+	if resp.Proof == nil {
+		return nil, ProofMissingError
+	}
+	header, err := c.VerifiedCommitCtx(ctx, resp.Height+1)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+	if resp.Value == nil {
+		err = proofRuntime.VerifyAbsence(resp.Proof, header.AppHash, keyPath.String())
+	} else {
+		err = proofRuntime.VerifyValue(resp.Proof, header.AppHash, keyPath.String(), resp.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof of %X in store %s at height %d: %v", []byte(key), storeName, resp.Height, err)
+	}
+	return resp.Value, nil
+}
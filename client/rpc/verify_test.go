@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	gtypes "github.com/bnb-chain/go-sdk/types"
+)
+
+// signedHeader returns the header at height with appHash, committed by all of privVals.
+func signedHeader(t *testing.T, height int64, appHash []byte, vals *types.ValidatorSet, privVals []types.PrivValidator) types.SignedHeader {
+	header := &types.Header{ChainID: "test", Height: height, Time: tmtime.Now(), AppHash: appHash, ValidatorsHash: vals.Hash()}
+	// the votes are made in the order of the set
+	ordered := make([]types.PrivValidator, len(privVals))
+	for _, pv := range privVals {
+		i, _ := vals.GetByAddress(pv.GetPubKey().Address())
+		ordered[i] = pv
+	}
+	blockID := types.BlockID{Hash: header.Hash()}
+	commit, err := types.MakeCommit(blockID, height, 0, types.NewVoteSet("test", height, 0, types.PrecommitType, vals), ordered)
+	assert.NoError(t, err)
+	return types.SignedHeader{Header: header, Commit: commit}
+}
+
+func TestQueryStoreVerified(t *testing.T) {
+	// the acc store, with its app hash in the next header
+	tree := iavl.NewMutableTree(dbm.NewMemDB(), 0)
+	tree.Set([]byte("alice"), []byte("balance"))
+	carol := ntypes.AccAddress("verify-test-carol---")
+	account, err := gtypes.NewCodec().MarshalBinaryBare(&ntypes.AppAccount{BaseAccount: ntypes.BaseAccount{
+		Address: carol,
+		Coins:   ntypes.Coins{{Denom: "BNB", Amount: 100}},
+	}})
+	assert.NoError(t, err)
+	tree.Set(append([]byte("account:"), carol...), account)
+	storeHash, _, err := tree.SaveVersion()
+	assert.NoError(t, err)
+	multi := store.NewMultiStoreProof([]store.StoreInfo{
+		{Name: "acc", Core: store.StoreCore{CommitID: store.CommitID{Version: 9, Hash: storeHash}}},
+		{Name: "bank", Core: store.StoreCore{CommitID: store.CommitID{Version: 9, Hash: []byte("bank")}}},
+	})
+
+	trusted, trustedPrivVals := types.RandValidatorSet(4, 10)
+	newVal, newPrivVal := types.RandValidator(false, 10)
+	changed := types.NewValidatorSet(append(trusted.Copy().Validators, newVal))
+	var forge, change, stale int32
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		// the validator set changes at height 20
+		height, vals, privVals := int64(10), trusted, trustedPrivVals
+		if atomic.LoadInt32(&change) == 1 {
+			height, vals, privVals = 20, changed, append(trustedPrivVals, newPrivVal)
+		}
+		switch req.Method {
+		case "status":
+			conn.reply(id, ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: height}})
+		case "abci_query":
+			var params struct {
+				Data   cmn.HexBytes `json:"data"`
+				Height string       `json:"height"`
+				Prove  bool         `json:"prove"`
+			}
+			assert.NoError(t, json.Unmarshal(req.Params, &params))
+			assert.Equal(t, strconv.FormatInt(height-1, 10), params.Height)
+			assert.True(t, params.Prove)
+			value, proof, err := tree.GetVersionedWithProof(params.Data, 1)
+			assert.NoError(t, err)
+			op := iavl.NewIAVLAbsenceOp(params.Data, proof).ProofOp()
+			if value != nil {
+				op = iavl.NewIAVLValueOp(params.Data, proof).ProofOp()
+			}
+			if atomic.LoadInt32(&forge) == 1 {
+				value = []byte("forged")
+			}
+			answered := height - 1
+			if atomic.LoadInt32(&stale) == 1 {
+				answered--
+			}
+			conn.reply(id, ctypes.ResultABCIQuery{Response: abci.ResponseQuery{
+				Key:    params.Data,
+				Value:  value,
+				Height: answered,
+				Proof:  &merkle.Proof{Ops: []merkle.ProofOp{op, store.NewMultiStoreProofOp([]byte("acc"), multi).ProofOp()}},
+			}})
+		case "commit":
+			conn.reply(id, ctypes.ResultCommit{SignedHeader: signedHeader(t, height, multi.ComputeRootHash(), vals, privVals), CanonicalCommit: true})
+		case "validators":
+			conn.reply(id, ctypes.ResultValidators{BlockHeight: height, Validators: vals.Validators})
+		}
+		return true
+	})
+	defer server.Close()
+	defer c.Stop()
+
+	verifier := NewVerifier("test", 1, trusted)
+	c.SetVerifier(verifier)
+	value, err := c.QueryStore([]byte("alice"), "acc")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("balance"), value)
+	value, err = c.QueryStore([]byte("bob"), "acc")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	_, err = c.QueryStoreSubspace([]byte("a"), "acc")
+	assert.Equal(t, SubspaceNotVerifiableError, err)
+
+	// the accounts are read from the store, with a proof
+	balances, err := c.GetBalances(carol)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, int64(100), balances[0].Free.ToInt64())
+	acc, err := c.GetAccount(ntypes.AccAddress("verify-test-dave----"))
+	assert.NoError(t, err)
+	assert.Nil(t, acc)
+
+	atomic.StoreInt32(&forge, 1)
+	_, err = c.QueryStore([]byte("alice"), "acc")
+	assert.Error(t, err)
+	_, err = c.GetBalances(carol)
+	assert.Error(t, err)
+	atomic.StoreInt32(&forge, 0)
+
+	// answered at another height than asked
+	atomic.StoreInt32(&stale, 1)
+	_, err = c.QueryStore([]byte("alice"), "acc")
+	assert.Error(t, err)
+	atomic.StoreInt32(&stale, 0)
+
+	// signed by another chain, or by validators not trusted
+	c.SetVerifier(NewVerifier("other", 1, trusted))
+	_, err = c.QueryStore([]byte("alice"), "acc")
+	assert.Error(t, err)
+	untrusted, _ := types.RandValidatorSet(4, 10)
+	c.SetVerifier(NewVerifier("test", 1, untrusted))
+	_, err = c.QueryStore([]byte("alice"), "acc")
+	assert.Error(t, err)
+
+	// a new validator signed with all the trusted ones, the new set is trusted then
+	c.SetVerifier(verifier)
+	atomic.StoreInt32(&change, 1)
+	value, err = c.QueryStore([]byte("alice"), "acc")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("balance"), value)
+	assert.Equal(t, changed.Hash(), verifier.validators.Hash())
+}
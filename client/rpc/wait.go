@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+const (
+	defaultWaitTimeout      = time.Minute
+	defaultWaitPollInterval = time.Second
+)
+
+// WaitOptions controls how BroadcastAndWait tracks a tx after broadcasting it.
+type WaitOptions struct {
+	// Timeout bounds the whole call, one minute if zero.
+	Timeout time.Duration
+	// Confirmations is the number of blocks to wait for on top of the one including the tx.
+	Confirmations int64
+	// PollInterval is the delay between Tx and Status queries, one second if zero.
+	PollInterval time.Duration
+	// Subscribe also queries Tx as soon as the websocket Tx event is received. Tx is
+	// still polled, the event is lost if the websocket reconnects meanwhile.
+	Subscribe bool
+}
+
+// BroadcastAndWait broadcasts m with Sync, then waits until the tx is included in
+// a block and the requested confirmations have passed. The ResultTx is returned
+// together with an error when the tx failed in DeliverTx.
+func (c *HTTP) BroadcastAndWait(m msg.Msg, wait WaitOptions, options ...tx.Option) (*ResultTx, error) {
+	return c.BroadcastAndWaitCtx(context.Background(), m, wait, options...)
+}
+
+func (c *HTTP) BroadcastAndWaitCtx(ctx context.Context, m msg.Msg, wait WaitOptions, options ...tx.Option) (*ResultTx, error) {
+	if wait.Timeout <= 0 {
+		wait.Timeout = defaultWaitTimeout
+	}
+	if wait.PollInterval <= 0 {
+		wait.PollInterval = defaultWaitPollInterval
+	}
+	ctx, cancel := context.WithTimeout(ctx, wait.Timeout)
+	defer cancel()
+
+	signBz, done, err := c.sign(ctx, []msg.Msg{m}, options...)
+	if err != nil {
+		return nil, err
+	}
+	hash := tmhash.Sum(signBz)
+
+	// subscribe before broadcasting, the event may come before Sync returns
+	var included <-chan struct{}
+	if wait.Subscribe {
+		query := fmt.Sprintf("tm.event='Tx' AND tx.hash='%X'", hash)
+		events, err := c.Subscribe(query)
+		if err != nil {
+			done(err)
+			return nil, err
+		}
+		defer c.Unsubscribe(query)
+		ch := make(chan struct{})
+		go func() {
+			select {
+			case _, ok := <-events:
+				if ok {
+					close(ch)
+				}
+			case <-ctx.Done():
+			}
+		}()
+		included = ch
+	}
+
+	res, err := c.broadcastSigned(ctx, signBz, Sync, done)
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf("tx %X rejected by CheckTx: %w", hash, tx.NewABCIError(res.Code, res.Log))
+	}
+
+	result, err := c.pollTx(ctx, hash, wait.PollInterval, included)
+	if err != nil {
+		return nil, err
+	}
+	if wait.Confirmations > 0 {
+		if err := c.waitForHeight(ctx, result.Height+wait.Confirmations, wait.PollInterval); err != nil {
+			return result, err
+		}
+	}
+	if result.TxResult.Code != 0 {
+		return result, fmt.Errorf("tx %X failed at height %d: %w", hash, result.Height, tx.NewABCIError(result.TxResult.Code, result.TxResult.Log))
+	}
+	return result, nil
+}
+
+// pollTx queries hash until the node indexed it, every interval and once included is
+// closed. included may be nil.
+func (c *HTTP) pollTx(ctx context.Context, hash []byte, interval time.Duration, included <-chan struct{}) (*ResultTx, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		// the node answers not found until the tx is indexed, other errors are retried as well
+		result, err := c.TxCtx(ctx, hash, false)
+		if err == nil {
+			return result, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-included:
+			included = nil
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %X not included: %w", hash, ctx.Err())
+		}
+	}
+}
+
+// waitForHeight blocks until the latest block of the node reaches height.
+func (c *HTTP) waitForHeight(ctx context.Context, height int64, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := c.StatusCtx(ctx)
+		if err == nil && status.SyncInfo.LatestBlockHeight >= height {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("height %d not reached: %w", height, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/keys"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// newWaitTestNode accepts every tx, which is found by the tx query once foundAfter
+// queries failed, at height 5. The latest height is 6. The Tx event is sent after the
+// broadcast when event is set.
+func newWaitTestNode(t *testing.T, foundAfter int32, queries *int32, event bool) (func(), *HTTP, msg.Msg) {
+	var subID rpctypes.JSONRPCStringID
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		switch req.Method {
+		case "subscribe":
+			subID = id
+			conn.reply(id, struct{}{})
+		case "unsubscribe":
+			conn.reply(id, struct{}{})
+		case "broadcast_tx_sync":
+			conn.reply(id, ctypes.ResultBroadcastTx{})
+			if event && subID != "" {
+				conn.event(subID, ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: tmtypes.TxResult{Height: 5}}})
+			}
+		case "tx":
+			if atomic.AddInt32(queries, 1) <= foundAfter {
+				conn.replyError(id, errors.New("tx not found"))
+				return true
+			}
+			conn.reply(id, ResultTx{Height: 5})
+		case "status":
+			conn.reply(id, ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 6}})
+		}
+		return true
+	})
+	km, err := keys.NewKeyManager()
+	assert.NoError(t, err)
+	c.SetKeyManager(km)
+	coins := types.Coins{{Denom: "BNB", Amount: 1}}
+	m := msg.CreateSendMsg(km.GetAddr(), coins, []msg.Transfer{{ToAddr: km.GetAddr(), Coins: coins}})
+	return func() {
+		c.Stop()
+		server.Close()
+	}, c, m
+}
+
+func TestBroadcastAndWait(t *testing.T) {
+	var queries int32
+	stop, c, m := newWaitTestNode(t, 2, &queries, false)
+	defer stop()
+
+	res, err := c.BroadcastAndWait(m, WaitOptions{Confirmations: 1, PollInterval: 10 * time.Millisecond}, tx.WithAcNumAndSequence(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), res.Height)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&queries))
+
+	// not reached
+	_, err = c.BroadcastAndWait(m, WaitOptions{Confirmations: 2, Timeout: 100 * time.Millisecond, PollInterval: 10 * time.Millisecond}, tx.WithAcNumAndSequence(0, 1))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+}
+
+func TestBroadcastAndWaitSubscribe(t *testing.T) {
+	var queries int32
+	stop, c, m := newWaitTestNode(t, 1, &queries, true)
+	defer stop()
+
+	// the event is not waited for until the next poll
+	res, err := c.BroadcastAndWait(m, WaitOptions{Subscribe: true, Timeout: time.Second, PollInterval: time.Hour}, tx.WithAcNumAndSequence(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), res.Height)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&queries))
+}
+
+func TestBroadcastAndWaitSubscribeLostEvent(t *testing.T) {
+	var queries int32
+	stop, c, m := newWaitTestNode(t, 2, &queries, false)
+	defer stop()
+
+	// the event is lost, the tx is found by polling
+	res, err := c.BroadcastAndWait(m, WaitOptions{Subscribe: true, Timeout: time.Second, PollInterval: 10 * time.Millisecond}, tx.WithAcNumAndSequence(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), res.Height)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&queries))
+}
+
+func TestBroadcastAndWaitTimeout(t *testing.T) {
+	var queries int32
+	stop, c, m := newWaitTestNode(t, 1000, &queries, false)
+	defer stop()
+
+	start := time.Now()
+	_, err := c.BroadcastAndWait(m, WaitOptions{Timeout: 100 * time.Millisecond, PollInterval: 10 * time.Millisecond}, tx.WithAcNumAndSequence(0, 0))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.True(t, atomic.LoadInt32(&queries) > 1)
+}
+
+func TestBroadcastAndWaitCancel(t *testing.T) {
+	var queries int32
+	stop, c, m := newWaitTestNode(t, 1000, &queries, false)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := c.BroadcastAndWaitCtx(ctx, m, WaitOptions{PollInterval: 10 * time.Millisecond}, tx.WithAcNumAndSequence(0, 0))
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled), "%v", err)
+	assert.True(t, time.Since(start) < time.Second)
+}
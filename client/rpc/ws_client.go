@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"net"
-	"net/http"
 	"sync/atomic"
 
 	"fmt"
@@ -23,6 +22,8 @@ import (
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
 	"github.com/tendermint/tendermint/types"
 
+	"github.com/bnb-chain/go-sdk/client/interceptor"
+	"github.com/bnb-chain/go-sdk/common"
 	"github.com/bnb-chain/go-sdk/common/uuid"
 	"github.com/bnb-chain/go-sdk/types/tx"
 )
@@ -43,6 +44,9 @@ const (
 	EmptyRequest = rpctypes.JSONRPCStringID("")
 )
 
+// ErrNotConnected is returned when a request is not sent because the websocket is dialing or stopped.
+var ErrNotConnected = errors.New("websocket client is dialing or stopped, can't send any request")
+
 /** websocket event stuff here... **/
 type WSEvents struct {
 	cmn.BaseService
@@ -54,28 +58,67 @@ type WSEvents struct {
 	mtx       sync.RWMutex
 	reconnect chan *WSClient
 
-	subscriptionsQuitMap map[string]chan struct{}
-	subscriptionsIdMap   map[string]rpctypes.JSONRPCStringID
-	subscriptionSet      map[rpctypes.JSONRPCStringID]bool
+	subscriptionsMap   map[string]*subscription
+	subscriptionsIdMap map[string]rpctypes.JSONRPCStringID
+	subscriptionSet    map[rpctypes.JSONRPCStringID]bool
+
+	feedsMtx sync.Mutex
+	feeds    map[string]*feed
 
 	responsesCh chan rpctypes.RPCResponse
 
 	responseChanMap sync.Map
 
-	timeout time.Duration
+	timeout     time.Duration
+	transport   *common.TransportConfig
+	interceptor interceptor.Interceptor
+	onGap       func(Gap)
+	replay      bool
+}
+
+type subscription struct {
+	query    string
+	id       rpctypes.JSONRPCStringID
+	in       chan rpctypes.RPCResponse
+	overflow Overflow
+	// queue holds the events not handed to in yet with OverflowBlock
+	queue *eventQueue
+	quit  chan struct{}
+	gaps  chan struct{}
+	// lastHeight is only used by the goroutine forwarding the events
+	lastHeight int64
+}
+
+func newSubscription(query string, id rpctypes.JSONRPCStringID) *subscription {
+	return &subscription{
+		query: query,
+		id:    id,
+		quit:  make(chan struct{}),
+		gaps:  make(chan struct{}, 1),
+	}
+}
+
+// notifyGap never blocks, gaps not handled yet are merged.
+func (s *subscription) notifyGap() {
+	select {
+	case s.gaps <- struct{}{}:
+	default:
+	}
 }
 
-func newWSEvents(cdc *amino.Codec, remote, endpoint string) *WSEvents {
+func newWSEvents(cdc *amino.Codec, remote, endpoint string, transport *common.TransportConfig) *WSEvents {
 	wsEvents := &WSEvents{
-		cdc:                  cdc,
-		endpoint:             endpoint,
-		remote:               remote,
-		subscriptionsQuitMap: make(map[string]chan struct{}),
-		subscriptionsIdMap:   make(map[string]rpctypes.JSONRPCStringID),
-		subscriptionSet:      make(map[rpctypes.JSONRPCStringID]bool),
-		timeout:              DefaultTimeout,
-		responsesCh:          make(chan rpctypes.RPCResponse),
-		reconnect:            make(chan *WSClient),
+		cdc:                cdc,
+		endpoint:           endpoint,
+		remote:             remote,
+		transport:          transport,
+		subscriptionsMap:   make(map[string]*subscription),
+		subscriptionsIdMap: make(map[string]rpctypes.JSONRPCStringID),
+		subscriptionSet:    make(map[rpctypes.JSONRPCStringID]bool),
+		feeds:              make(map[string]*feed),
+		timeout:            DefaultTimeout,
+		responsesCh:        make(chan rpctypes.RPCResponse),
+		reconnect:          make(chan *WSClient),
 	}
 
 	wsEvents.BaseService = *cmn.NewBaseService(nil, "WSEvents", wsEvents)
@@ -84,13 +127,12 @@ func newWSEvents(cdc *amino.Codec, remote, endpoint string) *WSEvents {
 
 // OnStart implements cmn.Service by starting WSClient and event loop.
 func (w *WSEvents) OnStart() error {
-	wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter))
-	wsClient.SetCodec(w.cdc)
+	wsClient := w.newWSClient(false)
+	w.setWsClient(wsClient)
 	err := wsClient.Start()
 	if err != nil {
 		return err
 	}
-	w.setWsClient(wsClient)
 
 	go w.eventListener()
 	go w.reconnectRoutine()
@@ -126,11 +168,25 @@ func (c *WSEvents) IsActive() bool {
 
 // Subscribe implements EventsClient by using WSClient to subscribe given
 // subscriber to query. By default, returns a channel with cap=1. Error is
-// returned if it fails to subscribe.
+// returned if it fails to subscribe. The events that don't fit in the channel
+// are dropped, see SubscribeWithOptions to change it.
 // Channel is never closed to prevent clients from seeing an erroneus event.
 func (w *WSEvents) Subscribe(query string,
 	outCapacity ...int) (out chan ctypes.ResultEvent, err error) {
-	if _, ok := w.subscriptionsIdMap[query]; ok {
+	opts := SubscribeOptions{Capacity: 1}
+	if len(outCapacity) > 0 {
+		opts.Capacity = outCapacity[0]
+	}
+	return w.SubscribeWithOptions(query, opts)
+}
+
+// SubscribeWithOptions is Subscribe with the capacity of the channel and what to do
+// when it is full set by opts.
+func (w *WSEvents) SubscribeWithOptions(query string, opts SubscribeOptions) (out chan ctypes.ResultEvent, err error) {
+	w.mtx.RLock()
+	_, ok := w.subscriptionsIdMap[query]
+	w.mtx.RUnlock()
+	if ok {
 		return nil, errors.New("already subscribe")
 	}
 
@@ -138,28 +194,46 @@ func (w *WSEvents) Subscribe(query string,
 	if err != nil {
 		return nil, err
 	}
-	outCap := 1
-	if len(outCapacity) > 0 {
-		outCap = outCapacity[0]
+	outEvent := make(chan ctypes.ResultEvent, opts.capacity())
+	sub := newSubscription(query, id)
+	sub.in = make(chan rpctypes.RPCResponse, cap(outEvent))
+	sub.overflow = opts.Overflow
+	if sub.overflow == OverflowBlock {
+		sub.queue = newEventQueue()
 	}
-	outEvent := make(chan ctypes.ResultEvent, outCap)
-	outResp := make(chan rpctypes.RPCResponse, cap(outEvent))
-	w.responseChanMap.Store(id, outResp)
+	w.responseChanMap.Store(id, sub)
+	// the ack may come back before Subscribe returns
+	w.mtx.Lock()
+	w.subscriptionSet[id] = true
+	w.mtx.Unlock()
 	ctx, cancel := w.NewContext()
 	defer cancel()
 	err = w.getWsClient().Subscribe(ctx, id, query)
 	if err != nil {
+		w.mtx.Lock()
+		delete(w.subscriptionSet, id)
+		w.mtx.Unlock()
 		w.responseChanMap.Delete(id)
 		return nil, err
 	}
 
-	quit := make(chan struct{})
+	w.mtx.RLock()
+	replay := w.replay
+	w.mtx.RUnlock()
+	if replay {
+		// the replay after a reconnect starts from here if no event is received before
+		if status, err := w.StatusCtx(ctx); err == nil {
+			sub.lastHeight = status.SyncInfo.LatestBlockHeight
+		}
+	}
 	w.mtx.Lock()
-	w.subscriptionsQuitMap[query] = quit
+	w.subscriptionsMap[query] = sub
 	w.subscriptionsIdMap[query] = id
-	w.subscriptionSet[id] = true
 	w.mtx.Unlock()
-	go w.WaitForEventResponse(id, outResp, outEvent, quit)
+	go w.forwardEvents(sub, sub.in, outEvent)
+	if sub.queue != nil {
+		go w.relayEvents(sub)
+	}
 
 	return outEvent, nil
 }
@@ -178,11 +252,11 @@ func (w *WSEvents) Unsubscribe(query string) error {
 		delete(w.subscriptionSet, id)
 		w.responseChanMap.Delete(id)
 	}
-	if quit, ok := w.subscriptionsQuitMap[query]; ok {
-		close(quit)
+	if sub, ok := w.subscriptionsMap[query]; ok {
+		close(sub.quit)
 	}
 	delete(w.subscriptionsIdMap, query)
-	delete(w.subscriptionsQuitMap, query)
+	delete(w.subscriptionsMap, query)
 	w.mtx.Unlock()
 
 	return nil
@@ -201,14 +275,22 @@ func (w *WSEvents) UnsubscribeAll() error {
 	for _, id := range w.subscriptionsIdMap {
 		w.responseChanMap.Delete(id)
 	}
-	for _, quit := range w.subscriptionsQuitMap {
-		close(quit)
+	for _, sub := range w.subscriptionsMap {
+		close(sub.quit)
 	}
 	w.subscriptionSet = make(map[rpctypes.JSONRPCStringID]bool)
-	w.subscriptionsQuitMap = make(map[string]chan struct{})
+	w.subscriptionsMap = make(map[string]*subscription)
 	w.subscriptionsIdMap = make(map[string]rpctypes.JSONRPCStringID)
 	w.mtx.Unlock()
 
+	// the typed subscriptions get no more events
+	w.feedsMtx.Lock()
+	for _, f := range w.feeds {
+		close(f.quit)
+	}
+	w.feeds = make(map[string]*feed)
+	w.feedsMtx.Unlock()
+
 	return nil
 }
 
@@ -225,32 +307,19 @@ func (w *WSEvents) getWsClient() *WSClient {
 }
 
 func (w *WSEvents) WaitForEventResponse(requestId interface{}, in chan rpctypes.RPCResponse, eventOut chan ctypes.ResultEvent, quit chan struct{}) {
-
-	for {
-		select {
-		case <-quit:
-			return
-		case resp, ok := <-in:
-			if !ok {
-				w.Logger.Info("channel of event stream is closed", "request id", requestId)
-				return
-			}
-			if resp.Error != nil {
-				w.Logger.Error("receive error from event stream", "error", resp.Error)
-				continue
-			}
-			res := new(ctypes.ResultEvent)
-			err := w.cdc.UnmarshalJSON(resp.Result, res)
-			if err != nil {
-				w.Logger.Debug("receive unexpected data from event stream", "result", resp.Result)
-				continue
-			}
-			eventOut <- *res
-		}
-	}
+	id, _ := requestId.(rpctypes.JSONRPCStringID)
+	sub := newSubscription("", id)
+	sub.quit = quit
+	w.forwardEvents(sub, in, eventOut)
 }
 
 func (w *WSEvents) WaitForResponse(ctx context.Context, outChan chan rpctypes.RPCResponse, result interface{}, ws *WSClient) error {
+	return w.waitForResponse(context.Background(), ctx, outChan, result, ws)
+}
+
+// waitForResponse waits on callCtx, which is derived from parent. The connection is only
+// recycled when the client timeout fires, not when the caller cancels parent.
+func (w *WSEvents) waitForResponse(parent, callCtx context.Context, outChan chan rpctypes.RPCResponse, result interface{}, ws *WSClient) error {
 	select {
 	case resp, ok := <-outChan:
 		if !ok {
@@ -260,56 +329,123 @@ func (w *WSEvents) WaitForResponse(ctx context.Context, outChan chan rpctypes.RP
 			return resp.Error
 		}
 		return w.cdc.UnmarshalJSON(resp.Result, result)
-	case <-ctx.Done():
-		w.reconnect <- ws
-		return ctx.Err()
+	case <-callCtx.Done():
+		if parent.Err() == nil {
+			w.reconnect <- ws
+		}
+		return callCtx.Err()
 	}
 }
 
 func (w *WSEvents) SimpleCall(doRpc func(ctx context.Context, id rpctypes.JSONRPCStringID) error, ws *WSClient, proto interface{}) error {
+	return w.SimpleCallCtx(context.Background(), doRpc, ws, proto)
+}
+
+// SimpleCallCtx sends a request and waits for its response until ctx is done or
+// the client timeout set by SetTimeOut elapses, whichever comes first.
+func (w *WSEvents) SimpleCallCtx(ctx context.Context, doRpc func(ctx context.Context, id rpctypes.JSONRPCStringID) error, ws *WSClient, proto interface{}) error {
 	id, err := ws.GenRequestId()
 	if err != nil {
 		return err
 	}
+	// outChan is not closed, the listener may have loaded it before it is deleted.
+	// It is buffered, so a late response does not block the listener either.
 	outChan := make(chan rpctypes.RPCResponse, 1)
 	w.responseChanMap.Store(id, outChan)
-	defer close(outChan)
 	defer w.responseChanMap.Delete(id)
-	ctx, cancel := w.NewContext()
+	callCtx, cancel := context.WithTimeout(ctx, w.timeout)
 	defer cancel()
-	if err = doRpc(ctx, id); err != nil {
+	if err = doRpc(callCtx, id); err != nil {
 		return err
 	}
-	return w.WaitForResponse(ctx, outChan, proto, ws)
+	return w.waitForResponse(ctx, callCtx, outChan, proto, ws)
 }
 
 func (w *WSEvents) Status() (*ctypes.ResultStatus, error) {
+	return w.StatusCtx(context.Background())
+}
+
+// call is SimpleCallCtx, observed by the interceptors set with SetInterceptors. A query
+// or broadcast answered with a non-zero code is returned as a response, but the
+// interceptors see its *tx.ABCIError.
+func (w *WSEvents) call(ctx context.Context, call *interceptor.Call, doRpc func(ctx context.Context, id rpctypes.JSONRPCStringID) error, ws *WSClient, proto interface{}) error {
+	call.Client, call.Endpoint = interceptor.ClientRPC, w.remote
+	if call.Path != "" {
+		call.Route = interceptor.Route(call.Path)
+	}
+	w.mtx.RLock()
+	intercept := w.interceptor
+	w.mtx.RUnlock()
+	var respErr error
+	err := interceptor.Invoke(ctx, intercept, call, func(ctx context.Context, _ *interceptor.Call) error {
+		if err := w.SimpleCallCtx(ctx, doRpc, ws, proto); err != nil {
+			return err
+		}
+		respErr = responseError(proto)
+		return respErr
+	})
+	if respErr != nil && errors.Is(err, respErr) {
+		return nil
+	}
+	return err
+}
+
+// responseError returns the failure reported by the code of an ABCI query or broadcast
+// response, nil for the other results.
+func responseError(result interface{}) error {
+	switch r := result.(type) {
+	case *ctypes.ResultABCIQuery:
+		return tx.NewABCIError(r.Response.Code, r.Response.Log)
+	case *ctypes.ResultBroadcastTx:
+		return tx.NewABCIError(r.Code, r.Log)
+	case *ResultBroadcastTxCommit:
+		if r.CheckTx.IsErr() {
+			return tx.NewABCIError(r.CheckTx.Code, r.CheckTx.Log)
+		}
+		return tx.NewABCIError(r.DeliverTx.Code, r.DeliverTx.Log)
+	}
+	return nil
+}
+
+func (w *WSEvents) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
 	status := new(ctypes.ResultStatus)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.Status, wsClient, status)
+	err := w.call(ctx, &interceptor.Call{Method: "status"}, wsClient.Status, wsClient, status)
 	return status, err
 }
 
 func (w *WSEvents) ABCIInfo() (*ctypes.ResultABCIInfo, error) {
+	return w.ABCIInfoCtx(context.Background())
+}
+
+func (w *WSEvents) ABCIInfoCtx(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
 	info := new(ctypes.ResultABCIInfo)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.ABCIInfo, wsClient, info)
+	err := w.call(ctx, &interceptor.Call{Method: "abci_info"}, wsClient.ABCIInfo, wsClient, info)
 	return info, err
 }
 
 func (w *WSEvents) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	return w.ABCIQueryWithOptionsCtx(context.Background(), path, data, opts)
+}
+
+func (w *WSEvents) ABCIQueryWithOptionsCtx(ctx context.Context, path string, data cmn.HexBytes, opts client.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
 	abciQuery := new(ctypes.ResultABCIQuery)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "abci_query", Path: path}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.ABCIQueryWithOptions(ctx, id, path, data, opts)
 	}, wsClient, abciQuery)
 	return abciQuery, err
 }
 
 func (w *WSEvents) BroadcastTxCommit(tx types.Tx) (*ResultBroadcastTxCommit, error) {
+	return w.BroadcastTxCommitCtx(context.Background(), tx)
+}
+
+func (w *WSEvents) BroadcastTxCommitCtx(ctx context.Context, tx types.Tx) (*ResultBroadcastTxCommit, error) {
 	txCommit := new(ResultBroadcastTxCommit)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "broadcast_tx_commit", TxHash: fmt.Sprintf("%X", tx.Hash())}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.BroadcastTxCommit(ctx, id, tx)
 	}, wsClient, txCommit)
 	if err == nil {
@@ -319,90 +455,134 @@ func (w *WSEvents) BroadcastTxCommit(tx types.Tx) (*ResultBroadcastTxCommit, err
 }
 
 func (w *WSEvents) BroadcastTx(route string, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return w.BroadcastTxCtx(context.Background(), route, tx)
+}
+
+func (w *WSEvents) BroadcastTxCtx(ctx context.Context, route string, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	txRes := new(ctypes.ResultBroadcastTx)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: route, TxHash: fmt.Sprintf("%X", tx.Hash())}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.BroadcastTx(ctx, id, route, tx)
 	}, wsClient, txRes)
 	return txRes, err
 }
 
 func (w *WSEvents) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return w.UnconfirmedTxsCtx(context.Background(), limit)
+}
+
+func (w *WSEvents) UnconfirmedTxsCtx(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error) {
 	unConfirmTxs := new(ctypes.ResultUnconfirmedTxs)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "unconfirmed_txs"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.UnconfirmedTxs(ctx, id, limit)
 	}, wsClient, unConfirmTxs)
 	return unConfirmTxs, err
 }
 
 func (w *WSEvents) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
+	return w.NumUnconfirmedTxsCtx(context.Background())
+}
+
+func (w *WSEvents) NumUnconfirmedTxsCtx(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
 	numUnConfirmTxs := new(ctypes.ResultUnconfirmedTxs)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.NumUnconfirmedTxs, wsClient, numUnConfirmTxs)
+	err := w.call(ctx, &interceptor.Call{Method: "num_unconfirmed_txs"}, wsClient.NumUnconfirmedTxs, wsClient, numUnConfirmTxs)
 	return numUnConfirmTxs, err
 }
 
 func (w *WSEvents) NetInfo() (*ctypes.ResultNetInfo, error) {
+	return w.NetInfoCtx(context.Background())
+}
+
+func (w *WSEvents) NetInfoCtx(ctx context.Context) (*ctypes.ResultNetInfo, error) {
 	netInfo := new(ctypes.ResultNetInfo)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.NetInfo, wsClient, netInfo)
+	err := w.call(ctx, &interceptor.Call{Method: "net_info"}, wsClient.NetInfo, wsClient, netInfo)
 	return netInfo, err
 }
 
 func (w *WSEvents) DumpConsensusState() (*ctypes.ResultDumpConsensusState, error) {
+	return w.DumpConsensusStateCtx(context.Background())
+}
+
+func (w *WSEvents) DumpConsensusStateCtx(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
 	consensusState := new(ctypes.ResultDumpConsensusState)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.DumpConsensusState, wsClient, consensusState)
+	err := w.call(ctx, &interceptor.Call{Method: "dump_consensus_state"}, wsClient.DumpConsensusState, wsClient, consensusState)
 	return consensusState, err
 }
 
 func (w *WSEvents) ConsensusState() (*ctypes.ResultConsensusState, error) {
+	return w.ConsensusStateCtx(context.Background())
+}
+
+func (w *WSEvents) ConsensusStateCtx(ctx context.Context) (*ctypes.ResultConsensusState, error) {
 	consensusState := new(ctypes.ResultConsensusState)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.ConsensusState, wsClient, consensusState)
+	err := w.call(ctx, &interceptor.Call{Method: "consensus_state"}, wsClient.ConsensusState, wsClient, consensusState)
 	return consensusState, err
 }
 
 func (w *WSEvents) Health() (*ctypes.ResultHealth, error) {
+	return w.HealthCtx(context.Background())
+}
+
+func (w *WSEvents) HealthCtx(ctx context.Context) (*ctypes.ResultHealth, error) {
 	health := new(ctypes.ResultHealth)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.Health, wsClient, health)
+	err := w.call(ctx, &interceptor.Call{Method: "health"}, wsClient.Health, wsClient, health)
 	return health, err
 }
 
 func (w *WSEvents) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	return w.BlockchainInfoCtx(context.Background(), minHeight, maxHeight)
+}
+
+func (w *WSEvents) BlockchainInfoCtx(ctx context.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 
 	blocksInfo := new(ctypes.ResultBlockchainInfo)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "blockchain"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.BlockchainInfo(ctx, id, minHeight, maxHeight)
 	}, wsClient, blocksInfo)
 	return blocksInfo, err
 }
 
 func (w *WSEvents) Genesis() (*ctypes.ResultGenesis, error) {
+	return w.GenesisCtx(context.Background())
+}
+
+func (w *WSEvents) GenesisCtx(ctx context.Context) (*ctypes.ResultGenesis, error) {
 
 	genesis := new(ctypes.ResultGenesis)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(wsClient.Genesis, wsClient, genesis)
+	err := w.call(ctx, &interceptor.Call{Method: "genesis"}, wsClient.Genesis, wsClient, genesis)
 	return genesis, err
 }
 
 func (w *WSEvents) Block(height *int64) (*ctypes.ResultBlock, error) {
+	return w.BlockCtx(context.Background(), height)
+}
+
+func (w *WSEvents) BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
 	block := new(ctypes.ResultBlock)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "block"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.Block(ctx, id, height)
 	}, wsClient, block)
 	return block, err
 }
 
 func (w *WSEvents) BlockResults(height *int64) (*ResultBlockResults, error) {
+	return w.BlockResultsCtx(context.Background(), height)
+}
+
+func (w *WSEvents) BlockResultsCtx(ctx context.Context, height *int64) (*ResultBlockResults, error) {
 
 	block := new(ResultBlockResults)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "block_results"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.BlockResults(ctx, id, height)
 	}, wsClient, block)
 	if err == nil {
@@ -412,19 +592,27 @@ func (w *WSEvents) BlockResults(height *int64) (*ResultBlockResults, error) {
 }
 
 func (w *WSEvents) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	return w.CommitCtx(context.Background(), height)
+}
+
+func (w *WSEvents) CommitCtx(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
 	commit := new(ctypes.ResultCommit)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "commit"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.Commit(ctx, id, height)
 	}, wsClient, commit)
 	return commit, err
 }
 
 func (w *WSEvents) Tx(hash []byte, prove bool) (*ResultTx, error) {
+	return w.TxCtx(context.Background(), hash, prove)
+}
+
+func (w *WSEvents) TxCtx(ctx context.Context, hash []byte, prove bool) (*ResultTx, error) {
 
 	tx := new(ResultTx)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "tx"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.Tx(ctx, id, hash, prove)
 	}, wsClient, tx)
 	if err == nil {
@@ -434,10 +622,14 @@ func (w *WSEvents) Tx(hash []byte, prove bool) (*ResultTx, error) {
 }
 
 func (w *WSEvents) TxSearch(query string, prove bool, page, perPage int) (*ResultTxSearch, error) {
+	return w.TxSearchCtx(context.Background(), query, prove, page, perPage)
+}
+
+func (w *WSEvents) TxSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) (*ResultTxSearch, error) {
 
 	txs := new(ResultTxSearch)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "tx_search"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.TxSearch(ctx, id, query, prove, page, perPage)
 	}, wsClient, txs)
 	if err == nil {
@@ -447,10 +639,14 @@ func (w *WSEvents) TxSearch(query string, prove bool, page, perPage int) (*Resul
 }
 
 func (w *WSEvents) TxInfoSearch(query string, prove bool, page, perPage int) ([]Info, error) {
+	return w.TxInfoSearchCtx(context.Background(), query, prove, page, perPage)
+}
+
+func (w *WSEvents) TxInfoSearchCtx(ctx context.Context, query string, prove bool, page, perPage int) ([]Info, error) {
 
 	txs := new(ResultTxSearch)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "tx_search"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.TxSearch(ctx, id, query, prove, page, perPage)
 	}, wsClient, txs)
 	if err != nil {
@@ -460,9 +656,13 @@ func (w *WSEvents) TxInfoSearch(query string, prove bool, page, perPage int) ([]
 }
 
 func (w *WSEvents) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	return w.ValidatorsCtx(context.Background(), height)
+}
+
+func (w *WSEvents) ValidatorsCtx(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
 	validators := new(ctypes.ResultValidators)
 	wsClient := w.getWsClient()
-	err := w.SimpleCall(func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+	err := w.call(ctx, &interceptor.Call{Method: "validators"}, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
 		return wsClient.Validators(ctx, id, height)
 	}, wsClient, validators)
 	return validators, err
@@ -472,20 +672,56 @@ func (w *WSEvents) SetTimeOut(timeout time.Duration) {
 	w.timeout = timeout
 }
 
+// SetInterceptors observes every request with interceptors, the first one being the outermost.
+func (w *WSEvents) SetInterceptors(interceptors ...interceptor.Interceptor) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.interceptor = interceptor.Chain(interceptors...)
+}
+
 func (w *WSEvents) NewContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), w.timeout)
 }
 
-// After being reconnected, it is necessary to redo subscription to server
-// otherwise no data will be automatically received.
-func (w *WSEvents) redoSubscriptionsAfter() {
+// newWSClient returns a websocket client. A client replacing a lost one resubscribes to
+// the queries subscribed to so far once connected, the later ones are subscribed to
+// through it already.
+func (w *WSEvents) newWSClient(replacing bool) *WSClient {
+	var subs []*subscription
+	if replacing {
+		w.mtx.RLock()
+		for _, sub := range w.subscriptionsMap {
+			subs = append(subs, sub)
+		}
+		w.mtx.RUnlock()
+	}
+	var wsClient *WSClient
+	wsClient = NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(func() {
+		w.redoSubscriptionsAfter(wsClient, subs)
+	}), setTransport(w.transport))
+	wsClient.SetCodec(w.cdc)
+	wsClient.SetLogger(w.Logger)
+	return wsClient
+}
 
-	for q, id := range w.subscriptionsIdMap {
-		ctx, _ := context.WithTimeout(context.Background(), w.timeout)
-		err := w.getWsClient().Subscribe(ctx, id, q)
+// After being reconnected, it is necessary to redo subscription to server
+// otherwise no data will be automatically received. The events emitted while
+// the connection was down are lost, so every subscription is told about the gap.
+func (w *WSEvents) redoSubscriptionsAfter(wsClient *WSClient, subs []*subscription) {
+	for _, sub := range subs {
+		w.mtx.RLock()
+		subscribed := w.subscriptionsMap[sub.query] == sub
+		w.mtx.RUnlock()
+		if !subscribed {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+		err := wsClient.Subscribe(ctx, sub.id, sub.query)
+		cancel()
 		if err != nil {
 			w.Logger.Error("Failed to resubscribe", "err", err)
 		}
+		sub.notifyGap()
 	}
 }
 
@@ -505,16 +741,16 @@ func (w *WSEvents) reconnectRoutine() {
 		case <-checkTicker.C:
 			if !w.getWsClient().IsRunning() {
 				w.Logger.Info("ws client have been stopped, try start new one", "server", w.getWsClient())
-				wsClient := NewWSClient(w.remote, w.endpoint, w.responsesCh, setOnDialSuccess(w.redoSubscriptionsAfter))
-				wsClient.SetCodec(w.cdc)
+				wsClient := w.newWSClient(true)
+				// set before starting, the resubscriptions run as soon as it is connected
+				w.setWsClient(wsClient)
 				err := wsClient.Start()
 				// should not happen
 				if err != nil {
 					w.Logger.Error("wsClient start failed", "err", err)
 					continue
 				}
-				w.Logger.Info("ws client reconnect success", "server", w.getWsClient())
-				w.setWsClient(wsClient)
+				w.Logger.Info("ws client reconnect success", "server", wsClient)
 			}
 		}
 	}
@@ -532,22 +768,27 @@ func (w *WSEvents) eventListener() {
 				w.Logger.Error("unexpected request id type")
 				continue
 			}
-			if exist := w.subscriptionSet[id]; exist {
+			w.mtx.RLock()
+			exist := w.subscriptionSet[id]
+			w.mtx.RUnlock()
+			if exist {
 				// receive ack event, need ignore it
 				continue
 			}
 			idParts := strings.Split(string(id), "#")
 			realId := rpctypes.JSONRPCStringID(idParts[0])
 			if out, ok := w.responseChanMap.Load(realId); ok {
-				outChan, ok := out.(chan rpctypes.RPCResponse)
-				if !ok {
-					w.Logger.Error("unexpected data type in responseChanMap")
-					continue
-				}
-				select {
-				case outChan <- resp:
+				switch out := out.(type) {
+				case *subscription:
+					w.pushEvent(out, resp)
+				case chan rpctypes.RPCResponse:
+					select {
+					case out <- resp:
+					default:
+						w.Logger.Error("wanted to publish response, but out channel is full", "result", resp.Result)
+					}
 				default:
-					w.Logger.Error("wanted to publish response, but out channel is full", "result", resp.Result)
+					w.Logger.Error("unexpected data type in responseChanMap")
 				}
 			}
 		case <-w.Quit():
@@ -592,6 +833,8 @@ type WSClient struct {
 	protocol string
 
 	onDialSuccess func()
+
+	transport *common.TransportConfig
 }
 
 // NewWSClient returns a new client. See the commentary on the func(*WSClient)
@@ -599,9 +842,11 @@ type WSClient struct {
 // pong wait time. The endpoint argument must begin with a `/`.
 func NewWSClient(remoteAddr, endpoint string, responsesCh chan<- rpctypes.RPCResponse, options ...func(*WSClient)) *WSClient {
 	protocol, addr, dialer := makeHTTPDialer(remoteAddr)
-	// default to ws protocol, unless wss is explicitly specified
-	if protocol != "wss" {
-		protocol = "ws"
+	// default to ws protocol, unless wss or https is explicitly specified
+	if protocol == protoWSS || protocol == protoHTTPS {
+		protocol = protoWSS
+	} else {
+		protocol = protoWS
 	}
 
 	c := &WSClient{
@@ -615,12 +860,14 @@ func NewWSClient(remoteAddr, endpoint string, responsesCh chan<- rpctypes.RPCRes
 		protocol:    protocol,
 		responsesCh: responsesCh,
 		send:        make(chan rpctypes.RPCRequest),
+		transport:   common.NewTransportConfig(),
 	}
 	c.dialing.Store(true)
 	c.BaseService = *cmn.NewBaseService(nil, "WSClient", c)
 	for _, option := range options {
 		option(c)
 	}
+	c.protocol = c.transport.WSScheme(c.protocol)
 	return c
 }
 
@@ -641,6 +888,7 @@ func (c *WSClient) OnStart() error {
 		go c.dialRoutine()
 	} else {
 		c.dialing.Store(false)
+		c.connected()
 	}
 
 	c.startReadWriteRoutines()
@@ -680,7 +928,7 @@ func (c *WSClient) Send(ctx context.Context, request rpctypes.RPCRequest) error
 // Call the given method. See Send description.
 func (c *WSClient) Call(ctx context.Context, method string, id rpctypes.JSONRPCStringID, params map[string]interface{}) error {
 	if !c.IsActive() {
-		return errors.New("websocket client is dialing or stopped, can't send any request")
+		return ErrNotConnected
 	}
 	request, err := rpctypes.MapToRequest(c.cdc, id, method, params)
 	if err != nil {
@@ -709,21 +957,25 @@ func (c *WSClient) GenRequestId() (rpctypes.JSONRPCStringID, error) {
 // Private methods
 
 func (c *WSClient) dial() error {
-	dialer := &websocket.Dialer{
-		NetDial: c.Dialer,
-		Proxy:   http.ProxyFromEnvironment,
+	dialer := c.transport.WebsocketDialer()
+	// with a proxy, the proxy is dialed rather than the node
+	if c.transport.Proxy == nil {
+		dialer.NetDial = c.Dialer
 	}
-	rHeader := http.Header{}
-	conn, _, err := dialer.Dial(c.protocol+"://"+c.Address+c.Endpoint, rHeader)
+	conn, _, err := dialer.Dial(c.protocol+"://"+c.Address+c.Endpoint, c.transport.HTTPHeader())
 	if err != nil {
 		return err
 	}
 	// only do once during the lifecycle of WSClient
 	c.conn = conn
+	return nil
+}
+
+// connected runs onDialSuccess once the client is active, so that it can send requests.
+func (c *WSClient) connected() {
 	if c.onDialSuccess != nil {
 		go c.onDialSuccess()
 	}
-	return nil
 }
 
 func (c *WSClient) startReadWriteRoutines() {
@@ -746,6 +998,8 @@ func (c *WSClient) dialRoutine() {
 		case <-dialTicker.C:
 			err := c.dial()
 			if err == nil {
+				c.dialing.Store(false)
+				c.connected()
 				return
 			}
 		}
@@ -957,6 +1211,14 @@ func setOnDialSuccess(onDial func()) func(c *WSClient) {
 	}
 }
 
+func setTransport(transport *common.TransportConfig) func(c *WSClient) {
+	return func(c *WSClient) {
+		if transport != nil {
+			c.transport = transport
+		}
+	}
+}
+
 func makeHTTPDialer(remoteAddr string) (string, string, func(string, string) (net.Conn, error)) {
 	// protocol to use for http operations, to support both http and https
 	clientProtocol := protoHTTP
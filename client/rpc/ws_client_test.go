@@ -0,0 +1,149 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/interceptor/metrics"
+	"github.com/bnb-chain/go-sdk/common"
+)
+
+// testConn is a websocket connection of a testNode, n counting the connections from 1.
+type testConn struct {
+	n    int32
+	conn *websocket.Conn
+	cdc  *amino.Codec
+	mtx  sync.Mutex
+}
+
+func (c *testConn) reply(id rpctypes.JSONRPCStringID, result interface{}) {
+	bz, _ := c.cdc.MarshalJSON(result)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.conn.WriteJSON(rpctypes.RPCResponse{JSONRPC: "2.0", ID: id, Result: bz})
+}
+
+func (c *testConn) replyError(id rpctypes.JSONRPCStringID, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.conn.WriteJSON(rpctypes.RPCInternalError(id, err))
+}
+
+func (c *testConn) event(subID rpctypes.JSONRPCStringID, event ctypes.ResultEvent) {
+	c.reply(subID+"#event", event)
+}
+
+// newTestNode serves the websocket of a node, calling handle for every request. The
+// connection is closed once handle returns false.
+func newTestNode(t *testing.T, handle func(c *testConn, req rpctypes.RPCRequest) bool) (*httptest.Server, *HTTP) {
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	var conns int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		c := &testConn{n: atomic.AddInt32(&conns, 1), conn: conn, cdc: cdc}
+		for {
+			var req rpctypes.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			if !handle(c, req) {
+				return
+			}
+		}
+	}))
+	return server, NewHTTP("tcp://"+strings.TrimPrefix(server.URL, "http://"), "/websocket")
+}
+
+func TestResponseAfterCancel(t *testing.T) {
+	w := newWSEvents(amino.NewCodec(), "tcp://127.0.0.1:0", "/websocket", common.NewTransportConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	// the listener loads the channel of the call, which gives up before the response is sent
+	var outChan chan rpctypes.RPCResponse
+	err := w.SimpleCallCtx(ctx, func(ctx context.Context, id rpctypes.JSONRPCStringID) error {
+		out, ok := w.responseChanMap.Load(id)
+		assert.True(t, ok)
+		outChan = out.(chan rpctypes.RPCResponse)
+		cancel()
+		return nil
+	}, &WSClient{}, &ctypes.ResultStatus{})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, w.PendingRequest())
+	assert.NotPanics(t, func() {
+		select {
+		case outChan <- rpctypes.RPCResponse{}:
+		default:
+		}
+	})
+}
+
+func TestInterceptorABCIFailure(t *testing.T) {
+	server, c := newTestNode(t, func(conn *testConn, req rpctypes.RPCRequest) bool {
+		id := req.ID.(rpctypes.JSONRPCStringID)
+		switch req.Method {
+		case "abci_query":
+			conn.reply(id, ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: 65542, Log: "unknown query"}})
+		case "broadcast_tx_sync":
+			conn.reply(id, ctypes.ResultBroadcastTx{Code: 65540, Log: "unauthorized"})
+		case "status":
+			conn.reply(id, ctypes.ResultStatus{})
+		}
+		return true
+	})
+	defer server.Close()
+	m := metrics.NewMetrics("bnb")
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(m))
+	c.SetInterceptors(m.Interceptor())
+
+	// the failures are still returned as responses
+	query, err := c.ABCIQueryCtx(context.Background(), "/account", nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 65542, query.Response.Code)
+	res, err := c.BroadcastTxSyncCtx(context.Background(), types.Tx("tx"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 65540, res.Code)
+	_, err = c.StatusCtx(context.Background())
+	assert.NoError(t, err)
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	codes := make(map[string]string)
+	for _, family := range families {
+		if family.GetName() != "bnb_sdk_calls_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			var method, code string
+			for _, label := range metric.GetLabel() {
+				switch label.GetName() {
+				case "method":
+					method = label.GetValue()
+				case "code":
+					code = label.GetValue()
+				}
+			}
+			codes[method] = code
+		}
+	}
+	assert.Equal(t, map[string]string{"abci_query": "65542", "broadcast_tx_sync": "65540", "status": ""}, codes)
+}
@@ -0,0 +1,82 @@
+package streamer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Store persists the height of the last block handled, so that a Streamer resumes
+// after it.
+type Store interface {
+	// Load returns the height saved last, 0 if none was.
+	Load(ctx context.Context) (int64, error)
+	Save(ctx context.Context, height int64) error
+}
+
+// MemoryStore keeps the height in memory, for tests or when resuming is not needed.
+type MemoryStore struct {
+	mtx    sync.Mutex
+	height int64
+}
+
+func NewMemoryStore(height int64) *MemoryStore {
+	return &MemoryStore{height: height}
+}
+
+func (s *MemoryStore) Load(ctx context.Context) (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.height, nil
+}
+
+func (s *MemoryStore) Save(ctx context.Context, height int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.height = height
+	return nil
+}
+
+// FileStore keeps the height in a file, replaced atomically on every save.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(ctx context.Context) (int64, error) {
+	bz, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64)
+}
+
+func (s *FileStore) Save(ctx context.Context, height int64) error {
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(strconv.FormatInt(height, 10) + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
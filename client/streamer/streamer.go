@@ -0,0 +1,319 @@
+// Package streamer delivers the blocks of the chain in order, with their txs and msgs
+// decoded, resuming after the last block handled. It is meant for indexers and for
+// exchanges detecting deposits.
+package streamer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+const (
+	defaultWorkers      = 4
+	defaultPollInterval = time.Second
+)
+
+// ErrReorg is returned by Run when a block doesn't follow the one handled before it.
+// The blocks handled since the fork point must be reverted by the caller, and the
+// Confirmations raised.
+var ErrReorg = errors.New("block doesn't follow the previous one")
+
+// Client is the part of the rpc client used by the Streamer. *rpc.HTTP implements it.
+type Client interface {
+	StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error)
+	BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error)
+	BlockResultsCtx(ctx context.Context, height *int64) (*rpc.ResultBlockResults, error)
+}
+
+// Options of a Streamer. Zero values are replaced by defaults.
+type Options struct {
+	// StartHeight is the first block handled when the store has no height saved. The
+	// latest confirmed block is by default.
+	StartHeight int64
+	// Confirmations is how many blocks must be committed on top of a block before it
+	// is handled.
+	Confirmations int64
+	// Workers is the number of blocks fetched concurrently, 4 by default.
+	Workers int
+	// PollInterval is how often the node is asked for new blocks once caught up, 1s
+	// by default.
+	PollInterval time.Duration
+	// Retry controls the retries of the failed fetches.
+	Retry common.RetryOptions
+}
+
+// Block is a block with its txs decoded.
+type Block struct {
+	Height int64
+	Time   time.Time
+	Hash   cmn.HexBytes
+	Txs    []Tx
+
+	lastHash cmn.HexBytes
+}
+
+// Msgs returns the msgs of the successful txs of b, in order.
+func (b *Block) Msgs() []Msg {
+	var msgs []Msg
+	for _, t := range b.Txs {
+		if !t.OK() {
+			continue
+		}
+		for i, m := range t.Msgs {
+			msgs = append(msgs, Msg{
+				Height:  b.Height,
+				Time:    b.Time,
+				TxHash:  t.Hash,
+				TxIndex: t.Index,
+				Index:   i,
+				Memo:    t.Memo,
+				Msg:     m,
+			})
+		}
+	}
+	return msgs
+}
+
+// Tx is a tx of a block, successful or not.
+type Tx struct {
+	Hash   cmn.HexBytes
+	Index  uint32
+	Memo   string
+	Msgs   []msg.Msg
+	Result rpc.ResponseDeliverTx
+	// Err is the failure to decode the tx, Memo and Msgs are empty then.
+	Err error
+}
+
+// OK reports whether the tx was decoded and executed successfully.
+func (t Tx) OK() bool {
+	return t.Err == nil && t.Result.Code == 0
+}
+
+// Msg is a msg of a successful tx, e.g. a msg.SendMsg for deposits.
+type Msg struct {
+	Height  int64
+	Time    time.Time
+	TxHash  cmn.HexBytes
+	TxIndex uint32
+	// Index is the position of the msg in its tx.
+	Index int
+	Memo  string
+	Msg   msg.Msg
+}
+
+// Handler handles a block. The block is handled again after a restart if the height
+// could not be saved, so handlers should be idempotent.
+type Handler func(ctx context.Context, block *Block) error
+
+// Streamer fetches the confirmed blocks in parallel and hands them to a Handler in
+// order, saving the height of each block handled to a Store.
+type Streamer struct {
+	client Client
+	store  Store
+	opts   Options
+}
+
+func NewStreamer(client Client, store Store, opts Options) *Streamer {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultWorkers
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.Confirmations < 0 {
+		opts.Confirmations = 0
+	}
+	return &Streamer{client: client, store: store, opts: opts}
+}
+
+// Run hands the blocks to handle until ctx is done, handle fails, or a block can't be
+// fetched or saved. It never returns nil.
+func (s *Streamer) Run(ctx context.Context, handle Handler) error {
+	next, err := s.start(ctx)
+	if err != nil {
+		return err
+	}
+	var lastHash cmn.HexBytes
+	for {
+		target, err := s.confirmedHeight(ctx)
+		if err != nil {
+			return err
+		}
+		if next > target {
+			select {
+			case <-time.After(s.opts.PollInterval):
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if next, lastHash, err = s.handleRange(ctx, next, target, lastHash, handle); err != nil {
+			return err
+		}
+	}
+}
+
+// start returns the height of the first block to handle.
+func (s *Streamer) start(ctx context.Context) (int64, error) {
+	saved, err := s.store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load the height: %w", err)
+	}
+	if saved > 0 {
+		return saved + 1, nil
+	}
+	if s.opts.StartHeight > 0 {
+		return s.opts.StartHeight, nil
+	}
+	height, err := s.confirmedHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if height < 1 {
+		return 1, nil
+	}
+	return height, nil
+}
+
+// confirmedHeight returns the height of the latest block with enough confirmations.
+func (s *Streamer) confirmedHeight(ctx context.Context) (int64, error) {
+	var status *ctypes.ResultStatus
+	err := common.Retry(ctx, s.opts.Retry, nil, func() (err error) {
+		status, err = s.client.StatusCtx(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight - s.opts.Confirmations, nil
+}
+
+type fetched struct {
+	block *Block
+	err   error
+}
+
+// handleRange hands the blocks from height from to height to to handle. It returns the
+// height of the next block to handle and the hash of the last block handled.
+func (s *Streamer) handleRange(ctx context.Context, from, to int64, lastHash cmn.HexBytes, handle Handler) (int64, cmn.HexBytes, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// every block is fetched into its own channel, queued in order
+	queue := make(chan chan fetched, s.opts.Workers)
+	go func() {
+		defer close(queue)
+		for height := from; height <= to; height++ {
+			result := make(chan fetched, 1)
+			select {
+			case queue <- result:
+			case <-ctx.Done():
+				return
+			}
+			go func(height int64) {
+				block, err := s.fetch(ctx, height)
+				result <- fetched{block, err}
+			}(height)
+		}
+	}()
+
+	next := from
+	for result := range queue {
+		var f fetched
+		select {
+		case f = <-result:
+		case <-ctx.Done():
+			return next, lastHash, ctx.Err()
+		}
+		if f.err != nil {
+			return next, lastHash, fmt.Errorf("failed to fetch block %d: %w", next, f.err)
+		}
+		if lastHash != nil && !bytes.Equal(f.block.lastHash, lastHash) {
+			return next, lastHash, fmt.Errorf("block %d: %w", next, ErrReorg)
+		}
+		if err := handle(ctx, f.block); err != nil {
+			return next, lastHash, err
+		}
+		if err := s.store.Save(ctx, next); err != nil {
+			return next, lastHash, fmt.Errorf("failed to save height %d: %w", next, err)
+		}
+		next, lastHash = next+1, f.block.Hash
+	}
+	return next, lastHash, ctx.Err()
+}
+
+// fetch returns the block at height with its txs decoded.
+func (s *Streamer) fetch(ctx context.Context, height int64) (*Block, error) {
+	var block *Block
+	err := common.Retry(ctx, s.opts.Retry, nil, func() error {
+		res, err := s.client.BlockCtx(ctx, &height)
+		if err != nil {
+			return err
+		}
+		results, err := s.client.BlockResultsCtx(ctx, &height)
+		if err != nil {
+			return err
+		}
+		block, err = decodeBlock(res, results)
+		return err
+	})
+	return block, err
+}
+
+func decodeBlock(res *ctypes.ResultBlock, results *rpc.ResultBlockResults) (*Block, error) {
+	if res == nil || res.Block == nil {
+		return nil, errors.New("empty block")
+	}
+	txs := res.Block.Data.Txs
+	var deliverTxs []*rpc.ResponseDeliverTx
+	if results != nil && results.Results != nil {
+		deliverTxs = results.Results.DeliverTx
+	}
+	if len(deliverTxs) != len(txs) {
+		return nil, fmt.Errorf("block %d has %d txs but %d results", res.Block.Height, len(txs), len(deliverTxs))
+	}
+	block := &Block{
+		Height:   res.Block.Height,
+		Time:     res.Block.Time,
+		Txs:      make([]Tx, len(txs)),
+		lastHash: res.Block.LastBlockID.Hash,
+	}
+	if res.BlockMeta != nil {
+		block.Hash = res.BlockMeta.BlockID.Hash
+	} else {
+		block.Hash = res.Block.Hash()
+	}
+	for i, bz := range txs {
+		block.Txs[i] = decodeTx(bz, uint32(i), deliverTxs[i])
+	}
+	return block, nil
+}
+
+func decodeTx(bz types.Tx, index uint32, result *rpc.ResponseDeliverTx) Tx {
+	t := Tx{Hash: bz.Hash(), Index: index}
+	if result != nil {
+		t.Result = *result
+	}
+	parsed, err := rpc.ParseTx(tx.Cdc, bz)
+	if err != nil {
+		t.Err = err
+		return t
+	}
+	t.Msgs = parsed.GetMsgs()
+	if stdTx, ok := parsed.(tx.StdTx); ok {
+		t.Memo = stdTx.Memo
+	}
+	return t
+}
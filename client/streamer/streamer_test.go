@@ -0,0 +1,186 @@
+package streamer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common"
+	ntypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+type fakeClient struct {
+	mtx      sync.Mutex
+	latest   int64
+	txs      map[int64][]types.Tx
+	codes    map[int64][]uint32
+	forkedAt int64
+	failures map[int64]int
+}
+
+func blockHash(height int64) []byte {
+	return []byte(fmt.Sprintf("block %d", height))
+}
+
+func (c *fakeClient) StatusCtx(ctx context.Context) (*ctypes.ResultStatus, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: c.latest}}, nil
+}
+
+func (c *fakeClient) BlockCtx(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	// blocks arrive out of order
+	time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if *height > c.latest {
+		return nil, errors.New("height must be less than or equal to the current blockchain height")
+	}
+	if c.failures[*height] > 0 {
+		c.failures[*height]--
+		return nil, errors.New("timeout")
+	}
+	lastHash := blockHash(*height - 1)
+	if *height == c.forkedAt {
+		lastHash = []byte("fork")
+	}
+	block := &types.Block{
+		Header: types.Header{Height: *height, LastBlockID: types.BlockID{Hash: lastHash}},
+		Data:   types.Data{Txs: c.txs[*height]},
+	}
+	return &ctypes.ResultBlock{
+		BlockMeta: &types.BlockMeta{BlockID: types.BlockID{Hash: blockHash(*height)}},
+		Block:     block,
+	}, nil
+}
+
+func (c *fakeClient) BlockResultsCtx(ctx context.Context, height *int64) (*rpc.ResultBlockResults, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	results := &rpc.ABCIResponses{}
+	for i := range c.txs[*height] {
+		results.DeliverTx = append(results.DeliverTx, &rpc.ResponseDeliverTx{Code: c.codes[*height][i]})
+	}
+	return &rpc.ResultBlockResults{Height: *height, Results: results}, nil
+}
+
+func (c *fakeClient) setLatest(height int64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.latest = height
+}
+
+var errStop = errors.New("stop")
+
+// stopAt appends the blocks handled to blocks, stopping after height.
+func stopAt(height int64, blocks *[]*Block) Handler {
+	return func(ctx context.Context, block *Block) error {
+		*blocks = append(*blocks, block)
+		if block.Height == height {
+			return errStop
+		}
+		return nil
+	}
+}
+
+func TestStreamer(t *testing.T) {
+	alice, bob := ntypes.AccAddress("alice"), ntypes.AccAddress("bob")
+	coins := ntypes.Coins{{Denom: "BNB", Amount: 1}}
+	send, err := tx.Cdc.MarshalBinaryLengthPrefixed(tx.StdTx{Memo: "deposit", Msgs: []msg.Msg{msg.SendMsg{
+		Inputs:  []msg.Input{msg.NewInput(alice, coins)},
+		Outputs: []msg.Output{msg.NewOutput(bob, coins)},
+	}}})
+	assert.NoError(t, err)
+
+	c := &fakeClient{
+		latest:   10,
+		txs:      map[int64][]types.Tx{3: {send, send, []byte("garbage")}},
+		codes:    map[int64][]uint32{3: {0, 1, 0}},
+		failures: map[int64]int{2: 2},
+	}
+	store := NewMemoryStore(0)
+	s := NewStreamer(c, store, Options{
+		StartHeight:   1,
+		Confirmations: 2,
+		Workers:       3,
+		PollInterval:  10 * time.Millisecond,
+		Retry:         common.RetryOptions{BaseBackoff: time.Millisecond},
+	})
+
+	// the blocks come in order, and only once confirmed
+	var blocks []*Block
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		c.setLatest(12)
+	}()
+	assert.Equal(t, errStop, s.Run(context.Background(), stopAt(9, &blocks)))
+	for i, block := range blocks {
+		assert.EqualValues(t, i+1, block.Height)
+		assert.EqualValues(t, blockHash(block.Height), block.Hash)
+	}
+	assert.Len(t, blocks, 9)
+	height, _ := store.Load(context.Background())
+	assert.EqualValues(t, 8, height, "the height is saved once the block is handled")
+
+	// the txs and msgs are decoded
+	txs := blocks[2].Txs
+	assert.Len(t, txs, 3)
+	assert.True(t, txs[0].OK())
+	assert.Equal(t, "deposit", txs[0].Memo)
+	assert.False(t, txs[1].OK())
+	assert.Error(t, txs[2].Err)
+	msgs := blocks[2].Msgs()
+	assert.Len(t, msgs, 1)
+	assert.EqualValues(t, 3, msgs[0].Height)
+	assert.EqualValues(t, 0, msgs[0].TxIndex)
+	assert.Equal(t, "deposit", msgs[0].Memo)
+	assert.Equal(t, bob, msgs[0].Msg.(msg.SendMsg).Outputs[0].Address)
+
+	// it resumes after the height saved
+	blocks = nil
+	assert.Equal(t, errStop, s.Run(context.Background(), stopAt(10, &blocks)))
+	assert.Len(t, blocks, 2)
+	assert.EqualValues(t, 9, blocks[0].Height)
+
+	// a block not following the previous one stops it
+	c.forkedAt = 10
+	blocks = nil
+	err = NewStreamer(c, NewMemoryStore(7), Options{}).Run(context.Background(), stopAt(10, &blocks))
+	assert.True(t, errors.Is(err, ErrReorg))
+	assert.Len(t, blocks, 2)
+}
+
+func TestStreamerStartsAtLatest(t *testing.T) {
+	c := &fakeClient{latest: 20}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var blocks []*Block
+	err := NewStreamer(c, NewMemoryStore(0), Options{Confirmations: 5}).Run(ctx, stopAt(15, &blocks))
+	assert.Equal(t, errStop, err)
+	assert.Len(t, blocks, 1)
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "height"))
+	height, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, height)
+	assert.NoError(t, store.Save(ctx, 42))
+	assert.NoError(t, store.Save(ctx, 43))
+	height, err = store.Load(ctx)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 43, height)
+}
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"time"
@@ -54,7 +55,23 @@ type TransactionClient interface {
 	ListMiniPair(baseAssetSymbol string, quoteAssetSymbol string, initPrice int64, sync bool, options ...Option) (*ListMiniPairResult, error)
 	SetURI(symbol, tokenURI string, sync bool, options ...Option) (*SetUriResult, error)
 
+	// BroadcastCtx signs and posts an arbitrary msg, giving up once ctx is done.
+	BroadcastCtx(ctx context.Context, m msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error)
+	// BroadcastMsgsCtx signs all msgs into a single tx and posts it.
+	BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error)
+
+	// BuildUnsigned prepares an unsigned tx of msgs for keys.SignOffline. The account number
+	// and sequence of the first signer are queried unless given by options, no key is needed.
+	BuildUnsigned(msgs []msg.Msg, options ...Option) ([]byte, error)
+	BuildUnsignedCtx(ctx context.Context, msgs []msg.Msg, options ...Option) ([]byte, error)
+	// BroadcastSigned posts a tx returned by keys.SignOffline.
+	BroadcastSigned(signed []byte, sync bool) (*tx.TxCommitResult, error)
+	BroadcastSignedCtx(ctx context.Context, signed []byte, sync bool) (*tx.TxCommitResult, error)
+
 	GetKeyManager() keys.KeyManager
+	// SetSequenceManager makes transactions take account numbers and sequences from m
+	// instead of querying the account before each of them.
+	SetSequenceManager(m tx.SequenceManager)
 }
 
 type client struct {
@@ -62,69 +79,149 @@ type client struct {
 	queryClient query.QueryClient
 	keyManager  keys.KeyManager
 	chainId     string
+	seqManager  tx.SequenceManager
 }
 
 func NewClient(chainId string, keyManager keys.KeyManager, queryClient query.QueryClient, basicClient basic.BasicClient) TransactionClient {
-	return &client{basicClient, queryClient, keyManager, chainId}
+	return &client{basicClient: basicClient, queryClient: queryClient, keyManager: keyManager, chainId: chainId}
 }
 
 func (c *client) GetKeyManager() keys.KeyManager {
 	return c.keyManager
 }
 
-func (c *client) broadcastMsg(m msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error) {
-	// prepare message to sign
-	signMsg := &tx.StdSignMsg{
-		ChainID:       c.chainId,
-		AccountNumber: -1,
-		Sequence:      -1,
-		Memo:          "",
-		Msgs:          []msg.Msg{m},
-		Source:        tx.Source,
+func (c *client) SetSequenceManager(m tx.SequenceManager) {
+	c.seqManager = m
+}
+
+func (c *client) fetchAccount(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+	acc, err := c.queryClient.GetAccountCtx(ctx, addr.String())
+	if err != nil {
+		return 0, 0, err
 	}
+	return acc.Number, acc.Sequence, nil
+}
+
+func (c *client) broadcastMsg(m msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error) {
+	return c.BroadcastCtx(context.Background(), m, sync, options...)
+}
+
+func (c *client) BroadcastCtx(ctx context.Context, m msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error) {
+	return c.BroadcastMsgsCtx(ctx, []msg.Msg{m}, sync, options...)
+}
 
-	for _, op := range options {
-		signMsg = op(signMsg)
+func (c *client) BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, sync bool, options ...Option) (*tx.TxCommitResult, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msg to broadcast")
 	}
+	signMsg := tx.NewSignMsg(c.chainId, msgs, options...)
 
+	// done reports the CheckTx outcome when the sequence comes from the sequence manager
+	done := func(error) {}
 	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
 		fromAddr := c.keyManager.GetAddr()
-		acc, err := c.queryClient.GetAccount(fromAddr.String())
+		fetch := c.fetchAccount
+		if c.seqManager != nil {
+			fetch = func(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+				return c.seqManager.Next(ctx, addr, c.fetchAccount)
+			}
+		}
+		accNum, seq, err := fetch(ctx, fromAddr)
 		if err != nil {
 			return nil, err
 		}
-		signMsg.Sequence = acc.Sequence
-		signMsg.AccountNumber = acc.Number
+		signMsg.AccountNumber, signMsg.Sequence = accNum, seq
+		if c.seqManager != nil {
+			done = func(err error) {
+				c.seqManager.Done(fromAddr, seq, err)
+			}
+		}
 	}
 
-	// special logic for createOrder, to save account query
-	if orderMsg, ok := m.(msg.CreateOrderMsg); ok {
-		orderMsg.Id = msg.GenerateOrderID(signMsg.Sequence+1, c.keyManager.GetAddr())
-		signMsg.Msgs[0] = orderMsg
+	if err := tx.SetOrderIds(signMsg, c.keyManager.GetAddr()); err != nil {
+		done(err)
+		return nil, err
 	}
 
 	for _, m := range signMsg.Msgs {
 		if err := m.ValidateBasic(); err != nil {
+			done(err)
 			return nil, err
 		}
 	}
 
 	rawBz, err := c.keyManager.Sign(*signMsg)
 	if err != nil {
+		done(err)
 		return nil, err
 	}
+	return c.postSigned(ctx, rawBz, sync, done)
+}
+
+func (c *client) BuildUnsigned(msgs []msg.Msg, options ...Option) ([]byte, error) {
+	return c.BuildUnsignedCtx(context.Background(), msgs, options...)
+}
+
+func (c *client) BuildUnsignedCtx(ctx context.Context, msgs []msg.Msg, options ...Option) ([]byte, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no msg to sign")
+	}
+	signers := msgs[0].GetSigners()
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("msg %s has no signer", msgs[0].Type())
+	}
+	signMsg := tx.NewSignMsg(c.chainId, msgs, options...)
+	if signMsg.Sequence == -1 || signMsg.AccountNumber == -1 {
+		accNum, seq, err := c.fetchAccount(ctx, signers[0])
+		if err != nil {
+			return nil, err
+		}
+		signMsg.AccountNumber, signMsg.Sequence = accNum, seq
+	}
+	if err := tx.SetOrderIds(signMsg, signers[0]); err != nil {
+		return nil, err
+	}
+	for _, m := range signMsg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	return tx.EncodeUnsignedTx(*signMsg)
+}
+
+func (c *client) BroadcastSigned(signed []byte, sync bool) (*tx.TxCommitResult, error) {
+	return c.BroadcastSignedCtx(context.Background(), signed, sync)
+}
+
+func (c *client) BroadcastSignedCtx(ctx context.Context, signed []byte, sync bool) (*tx.TxCommitResult, error) {
+	rawBz, err := tx.SignedTxBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+	return c.postSigned(ctx, rawBz, sync, func(error) {})
+}
+
+// postSigned posts rawBz and reports the CheckTx outcome to done.
+func (c *client) postSigned(ctx context.Context, rawBz []byte, sync bool, done func(error)) (*tx.TxCommitResult, error) {
 	// Hex encoded signed transaction, ready to be posted to BncChain API
 	hexTx := []byte(hex.EncodeToString(rawBz))
 	param := map[string]string{}
 	if sync {
 		param["sync"] = "true"
 	}
-	commits, err := c.basicClient.PostTx(hexTx, param)
+	commits, err := c.basicClient.PostTxCtx(ctx, hexTx, param)
 	if err != nil {
+		done(err)
 		return nil, err
 	}
 	if len(commits) < 1 {
+		done(nil)
 		return nil, fmt.Errorf("Len of tx Commit result is less than 1 ")
 	}
+	if commits[0].Code != tx.CodeOk {
+		done(tx.NewABCIError(uint32(commits[0].Code), commits[0].Log))
+	} else {
+		done(nil)
+	}
 	return &commits[0], nil
 }
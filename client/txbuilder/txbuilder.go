@@ -0,0 +1,94 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/bnb-chain/go-sdk/client/rpc"
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// RPCBroadcaster is implemented by the RPC client.
+type RPCBroadcaster interface {
+	BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, syncType rpc.SyncType, options ...tx.Option) (*core_types.ResultBroadcastTx, error)
+}
+
+// RESTBroadcaster is implemented by the REST transaction client.
+type RESTBroadcaster interface {
+	BroadcastMsgsCtx(ctx context.Context, msgs []msg.Msg, sync bool, options ...tx.Option) (*tx.TxCommitResult, error)
+}
+
+// MaxMsgs is the number of msgs accepted in a tx by the chain, which rejects
+// the txs holding more than one msg.
+const MaxMsgs = tx.MaxMsgs
+
+// TxBuilder accumulates the msgs of a tx, validates them and computes the fee
+// before signing and broadcasting through either the RPC or the REST client.
+// Since the chain only accepts txs of MaxMsgs msgs, ValidateBasic fails and
+// nothing is broadcast when more are added.
+type TxBuilder struct {
+	msgs    []msg.Msg
+	options []tx.Option
+}
+
+func NewTxBuilder(msgs ...msg.Msg) *TxBuilder {
+	return &TxBuilder{msgs: append([]msg.Msg{}, msgs...)}
+}
+
+// AddMsg appends msgs to the tx.
+func (b *TxBuilder) AddMsg(msgs ...msg.Msg) *TxBuilder {
+	b.msgs = append(b.msgs, msgs...)
+	return b
+}
+
+// WithOptions sets options such as memo or source applied when signing.
+func (b *TxBuilder) WithOptions(options ...tx.Option) *TxBuilder {
+	b.options = append(b.options, options...)
+	return b
+}
+
+func (b *TxBuilder) Msgs() []msg.Msg {
+	return append([]msg.Msg{}, b.msgs...)
+}
+
+// ValidateBasic checks the tx holds at least one and at most MaxMsgs msgs, and that
+// every msg is valid.
+func (b *TxBuilder) ValidateBasic() error {
+	if len(b.msgs) == 0 {
+		return fmt.Errorf("no msg in tx")
+	}
+	if len(b.msgs) > MaxMsgs {
+		return fmt.Errorf("%d msgs in tx, the chain accepts at most %d", len(b.msgs), MaxMsgs)
+	}
+	for i, m := range b.msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return fmt.Errorf("msg %d (%s) is invalid: %v", i, m.Type(), err)
+		}
+	}
+	return nil
+}
+
+// Fee returns the total fee of the tx in the native token, given the params from GetFee.
+func (b *TxBuilder) Fee(params []types.FeeParam) (int64, error) {
+	return tx.CalcFee(params, b.msgs...)
+}
+
+// BroadcastRPC signs the tx with the key manager of c and broadcasts it.
+func (b *TxBuilder) BroadcastRPC(ctx context.Context, c RPCBroadcaster, syncType rpc.SyncType) (*core_types.ResultBroadcastTx, error) {
+	if err := b.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return c.BroadcastMsgsCtx(ctx, b.msgs, syncType, b.options...)
+}
+
+// BroadcastREST signs the tx with the key manager of c and posts it.
+func (b *TxBuilder) BroadcastREST(ctx context.Context, c RESTBroadcaster, sync bool) (*tx.TxCommitResult, error) {
+	if err := b.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return c.BroadcastMsgsCtx(ctx, b.msgs, sync, b.options...)
+}
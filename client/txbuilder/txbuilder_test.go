@@ -0,0 +1,21 @@
+package txbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+func TestValidateBasic(t *testing.T) {
+	from, to := types.AccAddress("from-address--------"), types.AccAddress("to-address----------")
+	coins := types.Coins{{Denom: "BNB", Amount: 100}}
+	send := msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}})
+
+	assert.Error(t, NewTxBuilder().ValidateBasic())
+	assert.NoError(t, NewTxBuilder(send).ValidateBasic())
+	assert.Error(t, NewTxBuilder(send).AddMsg(send).ValidateBasic())
+	assert.Error(t, NewTxBuilder(msg.CreateSendMsg(from, coins, nil)).ValidateBasic())
+}
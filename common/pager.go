@@ -0,0 +1,72 @@
+package common
+
+import "context"
+
+// DefaultPageLimit is the number of items fetched per page by the iterators when no
+// limit is given.
+const DefaultPageLimit = 100
+
+// PageFetcher fetches the page of a list query starting at offset, keeping the items
+// aside. It returns how many it got and whether the page is the last one.
+type PageFetcher func(ctx context.Context, offset, limit int) (n int, last bool, err error)
+
+// Pager walks the items of a list query page by page, for the typed iterators. The
+// walk stops after the last page, an empty page, or the first error.
+type Pager struct {
+	limit  int
+	fetch  PageFetcher
+	offset int
+	n      int
+	i      int
+	last   bool
+	err    error
+}
+
+func NewPager(limit int, fetch PageFetcher) *Pager {
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+	return &Pager{limit: limit, fetch: fetch, i: -1}
+}
+
+// Next moves to the next item, fetching the next page when needed. It returns false
+// once there are no more items or the fetch failed, see Err.
+func (p *Pager) Next() bool {
+	return p.NextCtx(context.Background())
+}
+
+func (p *Pager) NextCtx(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+	if p.i+1 < p.n {
+		p.i++
+		return true
+	}
+	if p.last {
+		return false
+	}
+	n, last, err := p.fetch(ctx, p.offset, p.limit)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.offset += n
+	p.n, p.i = n, 0
+	p.last = last || n == 0
+	return n > 0
+}
+
+// Index is the position of the current item in the page fetched last.
+func (p *Pager) Index() int {
+	return p.i
+}
+
+// Limit is the number of items asked for per page.
+func (p *Pager) Limit() int {
+	return p.limit
+}
+
+func (p *Pager) Err() error {
+	return p.err
+}
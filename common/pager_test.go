@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPager(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	var page []int
+	var offsets []int
+	p := NewPager(3, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		offsets = append(offsets, offset)
+		end := offset + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		page = items[offset:end]
+		return len(page), len(page) < limit, nil
+	})
+	var got []int
+	for p.Next() {
+		got = append(got, page[p.Index()])
+	}
+	assert.NoError(t, p.Err())
+	assert.Equal(t, items, got)
+	assert.Equal(t, []int{0, 3, 6}, offsets)
+	assert.False(t, p.Next(), "no page is fetched after the last one")
+	assert.Len(t, offsets, 3)
+
+	// a full last page ends with an empty one
+	items = items[:6]
+	offsets = nil
+	p = NewPager(3, p.fetch)
+	got = nil
+	for p.Next() {
+		got = append(got, page[p.Index()])
+	}
+	assert.Equal(t, items, got)
+	assert.Equal(t, []int{0, 3, 6}, offsets)
+
+	fail := errors.New("fail")
+	p = NewPager(0, func(ctx context.Context, offset, limit int) (int, bool, error) {
+		assert.Equal(t, DefaultPageLimit, limit)
+		return 0, false, fail
+	})
+	assert.False(t, p.Next())
+	assert.Equal(t, fail, p.Err())
+}
@@ -0,0 +1,220 @@
+package common
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts         = 3
+	defaultBaseBackoff         = 100 * time.Millisecond
+	defaultMaxBackoff          = 2 * time.Second
+	defaultMaxLagBlocks        = 10
+	defaultHealthCheckInterval = 30 * time.Second
+)
+
+// RetryOptions controls retries and failover of the multi endpoint clients. Zero
+// values are replaced by defaults.
+type RetryOptions struct {
+	// MaxAttempts is the number of tries of a query, across endpoints.
+	MaxAttempts int
+	// BaseBackoff and MaxBackoff bound the jittered exponential delay between tries.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// MaxLagBlocks is how far behind the highest endpoint an endpoint may be and still be healthy.
+	MaxLagBlocks int64
+	// HealthCheckInterval is how often endpoints are checked again.
+	HealthCheckInterval time.Duration
+}
+
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultMaxAttempts
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = defaultBaseBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+	if o.MaxLagBlocks <= 0 {
+		o.MaxLagBlocks = defaultMaxLagBlocks
+	}
+	if o.HealthCheckInterval <= 0 {
+		o.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	return o
+}
+
+// Backoff returns a random delay up to BaseBackoff * 2^(attempt-1), capped by MaxBackoff.
+func (o RetryOptions) Backoff(attempt int) time.Duration {
+	o = o.withDefaults()
+	d := o.MaxBackoff
+	if attempt < 30 {
+		if exp := o.BaseBackoff << uint(attempt-1); exp > 0 && exp < d {
+			d = exp
+		}
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+// EndpointStatus is the outcome of the last health check of an endpoint.
+type EndpointStatus struct {
+	Endpoint   string
+	Healthy    bool
+	Height     int64
+	CatchingUp bool
+	Err        error
+	CheckedAt  time.Time
+}
+
+// HealthCheck reports the latest height of the endpoint at index i and whether it is catching up.
+type HealthCheck func(ctx context.Context, i int) (height int64, catchingUp bool, err error)
+
+// EndpointPool tracks the health of a list of endpoints and picks the one to call.
+// It is safe for concurrent use.
+type EndpointPool struct {
+	opts  RetryOptions
+	check HealthCheck
+
+	mtx       sync.Mutex
+	statuses  []EndpointStatus
+	current   int
+	checkedAt time.Time
+}
+
+func NewEndpointPool(endpoints []string, opts RetryOptions, check HealthCheck) *EndpointPool {
+	statuses := make([]EndpointStatus, len(endpoints))
+	for i, endpoint := range endpoints {
+		// endpoints are assumed healthy until checked
+		statuses[i] = EndpointStatus{Endpoint: endpoint, Healthy: true}
+	}
+	return &EndpointPool{opts: opts.withDefaults(), check: check, statuses: statuses}
+}
+
+// CheckHealth checks every endpoint concurrently. An endpoint is healthy when it answers,
+// is not catching up and is at most MaxLagBlocks behind the highest endpoint.
+func (p *EndpointPool) CheckHealth(ctx context.Context) []EndpointStatus {
+	results := make([]EndpointStatus, len(p.statuses))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			height, catchingUp, err := p.check(ctx, i)
+			results[i] = EndpointStatus{Height: height, CatchingUp: catchingUp, Err: err, CheckedAt: time.Now()}
+		}(i)
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, r := range results {
+		if r.Err == nil && r.Height > maxHeight {
+			maxHeight = r.Height
+		}
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for i := range results {
+		results[i].Endpoint = p.statuses[i].Endpoint
+		results[i].Healthy = results[i].Err == nil && !results[i].CatchingUp && results[i].Height >= maxHeight-p.opts.MaxLagBlocks
+	}
+	p.statuses = results
+	p.checkedAt = time.Now()
+	if !p.statuses[p.current].Healthy {
+		p.advance()
+	}
+	return append([]EndpointStatus{}, results...)
+}
+
+func (p *EndpointPool) Statuses() []EndpointStatus {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]EndpointStatus{}, p.statuses...)
+}
+
+// Pick returns the endpoint to call, running the health checks first when they are due.
+func (p *EndpointPool) Pick(ctx context.Context) int {
+	p.mtx.Lock()
+	due := time.Since(p.checkedAt) >= p.opts.HealthCheckInterval
+	if due {
+		// concurrent callers keep using the current endpoint meanwhile
+		p.checkedAt = time.Now()
+	}
+	p.mtx.Unlock()
+	if due {
+		p.CheckHealth(ctx)
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.current
+}
+
+// MarkFailed marks endpoint i unhealthy until the next health check and moves on to the next one.
+func (p *EndpointPool) MarkFailed(i int, err error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.statuses[i].Healthy = false
+	p.statuses[i].Err = err
+	if i == p.current {
+		p.advance()
+	}
+}
+
+// advance moves current to the next healthy endpoint, or just the next one when none is healthy.
+func (p *EndpointPool) advance() {
+	n := len(p.statuses)
+	for step := 1; step <= n; step++ {
+		if i := (p.current + step) % n; p.statuses[i].Healthy {
+			p.current = i
+			return
+		}
+	}
+	p.current = (p.current + 1) % n
+}
+
+// Do calls call with the endpoint to use until it succeeds, fails with an error that is
+// not retryable, or MaxAttempts is reached. Endpoints failing with a retryable error are
+// marked unhealthy, and the next attempt goes to the next endpoint after a backoff.
+// Only idempotent calls may be retried.
+func (p *EndpointPool) Do(ctx context.Context, retryable func(error) bool, call func(i int) error) error {
+	var err error
+	for attempt := 1; attempt <= p.opts.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(p.opts.Backoff(attempt - 1)):
+			case <-ctx.Done():
+				return err
+			}
+		}
+		i := p.Pick(ctx)
+		if err = call(i); err == nil || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		p.MarkFailed(i, err)
+	}
+	return err
+}
+
+// Retry calls call until it succeeds, fails with an error that is not retryable, or
+// MaxAttempts is reached, waiting a backoff between tries. A nil retryable retries
+// every error.
+func Retry(ctx context.Context, opts RetryOptions, retryable func(error) bool, call func() error) error {
+	opts = opts.withDefaults()
+	var err error
+	for attempt := 1; attempt <= opts.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(opts.Backoff(attempt - 1)):
+			case <-ctx.Done():
+				return err
+			}
+		}
+		if err = call(); err == nil || (retryable != nil && !retryable(err)) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
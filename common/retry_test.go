@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointPool(t *testing.T) {
+	heights := []int64{100, 200, 199}
+	catchingUp := []bool{false, false, true}
+	pool := NewEndpointPool([]string{"a", "b", "c"}, RetryOptions{BaseBackoff: time.Millisecond}, func(ctx context.Context, i int) (int64, bool, error) {
+		return heights[i], catchingUp[i], nil
+	})
+
+	// a lags behind and c is catching up
+	statuses := pool.CheckHealth(context.Background())
+	assert.False(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+	assert.False(t, statuses[2].Healthy)
+	assert.Equal(t, 1, pool.Pick(context.Background()))
+
+	errDown := errors.New("down")
+	var called []int
+	err := pool.Do(context.Background(), func(err error) bool { return err == errDown }, func(i int) error {
+		called = append(called, i)
+		if i == 1 {
+			return errDown
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, called)
+
+	errFatal := errors.New("fatal")
+	called = nil
+	err = pool.Do(context.Background(), func(err error) bool { return err == errDown }, func(i int) error {
+		called = append(called, i)
+		return errFatal
+	})
+	assert.Equal(t, errFatal, err)
+	assert.Len(t, called, 1)
+}
+
+func TestBackoff(t *testing.T) {
+	opts := RetryOptions{BaseBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for attempt := 1; attempt < 100; attempt++ {
+		d := opts.Backoff(attempt)
+		assert.True(t, d > 0 && d <= 50*time.Millisecond)
+	}
+	assert.True(t, opts.Backoff(1) <= 10*time.Millisecond)
+}
+
+func TestRetry(t *testing.T) {
+	opts := RetryOptions{MaxAttempts: 3, BaseBackoff: time.Millisecond}
+	calls := 0
+	err := Retry(context.Background(), opts, nil, func() error {
+		calls++
+		if calls < 2 {
+			return errors.New("timeout")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// errors not retryable are returned at once
+	calls = 0
+	fatal := errors.New("fatal")
+	err = Retry(context.Background(), opts, func(err error) bool { return err != fatal }, func() error {
+		calls++
+		return fatal
+	})
+	assert.Equal(t, fatal, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,146 @@
+package common
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const wsHandshakeTimeout = 45 * time.Second
+
+// TransportConfig is how the REST, RPC and websocket clients connect to their endpoints.
+type TransportConfig struct {
+	// HTTPClient sends the REST requests. Its transport also provides the TLS config
+	// of websocket connections, unless set by WithTLSConfig.
+	HTTPClient *http.Client
+	TLSConfig  *tls.Config
+	// Headers are added to every request and websocket handshake.
+	Headers map[string]string
+	// Scheme is http or https, websockets use ws or wss accordingly.
+	Scheme string
+	Proxy  *url.URL
+}
+
+// TransportOption configures a TransportConfig.
+type TransportOption func(*TransportConfig)
+
+func WithHTTPClient(client *http.Client) TransportOption {
+	return func(c *TransportConfig) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTLSConfig sets custom CA bundles or client certificates.
+func WithTLSConfig(config *tls.Config) TransportOption {
+	return func(c *TransportConfig) {
+		c.TLSConfig = config
+	}
+}
+
+func WithHeaders(headers map[string]string) TransportOption {
+	return func(c *TransportConfig) {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			c.Headers[k] = v
+		}
+	}
+}
+
+// WithScheme sets http or https, e.g. http for a local node.
+func WithScheme(scheme string) TransportOption {
+	return func(c *TransportConfig) {
+		c.Scheme = scheme
+	}
+}
+
+func WithProxy(proxy *url.URL) TransportOption {
+	return func(c *TransportConfig) {
+		c.Proxy = proxy
+	}
+}
+
+func NewTransportConfig(options ...TransportOption) *TransportConfig {
+	c := &TransportConfig{}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WSScheme returns the websocket scheme matching Scheme, or def when Scheme is not set.
+func (c *TransportConfig) WSScheme(def string) string {
+	switch c.Scheme {
+	case "http", "ws":
+		return "ws"
+	case "https", "wss":
+		return "wss"
+	}
+	return def
+}
+
+// HTTPHeader returns Headers as an http.Header, for websocket handshakes.
+func (c *TransportConfig) HTTPHeader() http.Header {
+	header := http.Header{}
+	for k, v := range c.Headers {
+		header.Set(k, v)
+	}
+	return header
+}
+
+// NewHTTPClient returns a copy of HTTPClient, or a new client, using the TLS config and
+// proxy. HTTPClient and its transport are left as they are, the transport is cloned to
+// apply them, so it must be an *http.Transport.
+func (c *TransportConfig) NewHTTPClient() (*http.Client, error) {
+	client := &http.Client{}
+	if c.HTTPClient != nil {
+		copied := *c.HTTPClient
+		client = &copied
+	}
+	if c.TLSConfig == nil && c.Proxy == nil {
+		return client, nil
+	}
+	var tr *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		tr = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		tr = t.Clone()
+	default:
+		return nil, fmt.Errorf("can't set the TLS config or proxy of a %T transport", t)
+	}
+	if c.TLSConfig != nil {
+		tr.TLSClientConfig = c.TLSConfig
+	}
+	if c.Proxy != nil {
+		tr.Proxy = http.ProxyURL(c.Proxy)
+	}
+	client.Transport = tr
+	return client, nil
+}
+
+// WebsocketDialer returns a dialer using the TLS config and proxy of the transport.
+func (c *TransportConfig) WebsocketDialer() *websocket.Dialer {
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: wsHandshakeTimeout,
+		TLSClientConfig:  c.TLSConfig,
+	}
+	if c.HTTPClient != nil {
+		dialer.Jar = c.HTTPClient.Jar
+		if tr, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+			if dialer.TLSClientConfig == nil {
+				dialer.TLSClientConfig = tr.TLSClientConfig
+			}
+		}
+	}
+	if c.Proxy != nil {
+		dialer.Proxy = http.ProxyURL(c.Proxy)
+	}
+	return dialer
+}
@@ -35,7 +35,7 @@ var (
 
 func rpcClient() *rpc.HTTP {
 	sOnceClient.Do(func() {
-		sTestClientInstance = rpc.NewRPCClient(sNodeAddr, ctypes.ProdNetwork)
+		sTestClientInstance = rpc.NewRPCClient(endpoint("side-staking", sNodeAddr), ctypes.ProdNetwork)
 	})
 	return sTestClientInstance
 }
@@ -57,7 +57,7 @@ func startBnbchaind(t *testing.T) *exec.Cmd {
 
 func defaultClient() *rpc.HTTP {
 	onceClient.Do(func() {
-		testClientInstance = rpc.NewRPCClient(nodeAddr, ctypes.TestNetwork)
+		testClientInstance = rpc.NewRPCClient(endpoint("rpc", nodeAddr), ctypes.TestNetwork)
 	})
 	return testClientInstance
 }
@@ -489,6 +489,43 @@ func TestSendToken(t *testing.T) {
 	fmt.Println(string(bz))
 }
 
+func TestSimulate(t *testing.T) {
+	c := defaultClient()
+	ctypes.SetNetwork(ctypes.TestNetwork)
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	c.SetKeyManager(keyManager)
+	testacc, err := ctypes.AccAddressFromBech32(testAddress)
+	assert.NoError(t, err)
+	coins := ctypes.Coins{{"BNB", 100000}}
+	sendMsg := msg.CreateSendMsg(keyManager.GetAddr(), coins, []msg.Transfer{{testacc, coins}})
+	res, err := c.Simulate([]msg.Msg{sendMsg})
+	assert.NoError(t, err)
+	assert.True(t, res.OK(), fmt.Sprint(res.Problems))
+
+	tooMuch := ctypes.Coins{{"BNB", 9000000000000000000}}
+	sendMsg = msg.CreateSendMsg(keyManager.GetAddr(), tooMuch, []msg.Transfer{{testacc, tooMuch}})
+	res, err = c.Simulate([]msg.Msg{sendMsg})
+	assert.NoError(t, err)
+	assert.False(t, res.OK())
+}
+
+func TestBroadcastAndWait(t *testing.T) {
+	c := defaultClient()
+	ctypes.SetNetwork(ctypes.TestNetwork)
+	keyManager, err := keys.NewMnemonicKeyManager(mnemonic)
+	assert.NoError(t, err)
+	c.SetKeyManager(keyManager)
+	testacc, err := ctypes.AccAddressFromBech32(testAddress)
+	assert.NoError(t, err)
+	coins := ctypes.Coins{{"BNB", 100000}}
+	sendMsg := msg.CreateSendMsg(keyManager.GetAddr(), coins, []msg.Transfer{{testacc, coins}})
+	res, err := c.BroadcastAndWait(sendMsg, rpc.WaitOptions{Timeout: time.Minute, Confirmations: 1})
+	assert.NoError(t, err)
+	assert.True(t, res.Height > 0)
+	assert.Equal(t, uint32(0), res.TxResult.Code)
+}
+
 func TestQuerySideChainParam(t *testing.T) {
 	c := defaultClient()
 	ctypes.SetNetwork(ctypes.TestNetwork)
@@ -35,7 +35,7 @@ func TestTransProcess(t *testing.T) {
 	testAccount3 := testKeyManager3.GetAddr()
 
 	//-----   Init sdk  -------------
-	client, err := sdk.NewDexClient(baeUrl, ctypes.TestNetwork, keyManager)
+	client, err := sdk.NewDexClient(endpoint("rest", baeUrl), ctypes.TestNetwork, keyManager, restOptions()...)
 
 	assert.NoError(t, err)
 	nativeSymbol := types.NativeSymbol
@@ -173,7 +173,7 @@ func TestAtomicSwap(t *testing.T) {
 	assert.NoError(t, err)
 	testAccount2 := testKeyManager2.GetAddr()
 
-	client, err := sdk.NewDexClient(baeUrl, ctypes.TestNetwork, keyManager)
+	client, err := sdk.NewDexClient(endpoint("rest", baeUrl), ctypes.TestNetwork, keyManager, restOptions()...)
 	assert.NoError(t, err)
 
 	randomNumber := crypto.CRandBytes(32)
@@ -216,7 +216,7 @@ func TestAtomicSwap(t *testing.T) {
 	time2.Sleep(4 * time2.Second)
 	swapID2 := msg.CalculateSwapID(randomNumberHash, testAccount1, "")
 	depositAmount := ctypes.Coins{ctypes.Coin{"BTC-271", 1000}}
-	client1, err := sdk.NewDexClient(baeUrl, ctypes.TestNetwork, testKeyManager2)
+	client1, err := sdk.NewDexClient(endpoint("rest", baeUrl), ctypes.TestNetwork, testKeyManager2, restOptions()...)
 	assert.NoError(t, err)
 	_, err = client1.DepositHTLT(swapID2, depositAmount, true)
 	assert.NoError(t, err)
@@ -225,7 +225,7 @@ func TestAtomicSwap(t *testing.T) {
 	assert.NoError(t, err)
 	time2.Sleep(4 * time2.Second)
 
-	c := rpc.NewRPCClient("tcp://seed-pre-s3.binance.org:80", ctypes.TestNetwork)
+	c := rpc.NewRPCClient(endpoint("seed", "tcp://seed-pre-s3.binance.org:80"), ctypes.TestNetwork)
 	swap, err := c.GetSwapByID(swapID)
 	assert.NoError(t, err)
 
@@ -0,0 +1,73 @@
+package e2e
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bnb-chain/go-sdk/client/basic"
+	"github.com/bnb-chain/go-sdk/client/rpc/mock"
+	"github.com/bnb-chain/go-sdk/common"
+)
+
+// E2E_RECORD=record runs the tests against the testnet and writes what the clients
+// exchanged to the golden files of testdata, E2E_RECORD=replay runs them against the
+// golden files. The tests reach the testnet when it is not set.
+var (
+	recordMode   = os.Getenv("E2E_RECORD")
+	recordersMtx sync.Mutex
+	recorders    = map[string]*mock.Recorder{}
+)
+
+// endpoint returns the address the clients should use for the testnet endpoint addr,
+// recorded to testdata/name.json according to E2E_RECORD.
+func endpoint(name, addr string) string {
+	var mode mock.RecordMode
+	switch recordMode {
+	case "":
+		return addr
+	case "record":
+		mode = mock.ModeRecord
+	case "replay":
+		mode = mock.ModeReplay
+	default:
+		log.Fatalf("E2E_RECORD must be record or replay, not %q", recordMode)
+	}
+	recordersMtx.Lock()
+	defer recordersMtx.Unlock()
+	r, ok := recorders[name]
+	if !ok {
+		var err error
+		if r, err = mock.NewRecorder(filepath.Join("testdata", name+".json"), mode, addr); err != nil {
+			log.Fatalf("recorder of %s: %v", name, err)
+		}
+		recorders[name] = r
+	}
+	if strings.HasPrefix(addr, "tcp://") {
+		return r.NodeURI()
+	}
+	return r.Host()
+}
+
+// restOptions are the options of the REST clients of endpoint.
+func restOptions() []basic.Option {
+	if recordMode == "" {
+		return nil
+	}
+	return []basic.Option{basic.WithTransport(common.WithScheme("http"))}
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	for name, r := range recorders {
+		if err := r.Close(); err != nil {
+			fmt.Printf("recorder of %s: %v\n", name, err)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,229 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	ctypes "github.com/bnb-chain/go-sdk/common/types"
+)
+
+const serializedExtendedKeyLen = 4 + 1 + 4 + 4 + 32 + 33
+
+var (
+	xprvVersion = []byte{0x04, 0x88, 0xad, 0xe4}
+	xpubVersion = []byte{0x04, 0x88, 0xb2, 0x1e}
+)
+
+// ExtendedKey is a BIP 32 extended key, a private or public key with its chain code, which
+// serializes to the xprv and xpub strings of wallets. Children of an extended public key
+// can be derived without the private key, except hardened ones.
+type ExtendedKey struct {
+	// 32 bytes private key, or 33 bytes compressed public key
+	key         []byte
+	chainCode   [32]byte
+	depth       uint8
+	fingerprint [4]byte
+	childIndex  uint32
+	private     bool
+}
+
+// NewMasterExtendedKey returns the master extended private key of a BIP 32 seed.
+func NewMasterExtendedKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed length should be between 16 and 64 bytes, got %d", len(seed))
+	}
+	secret, chainCode := ComputeMastersFromSeed(seed)
+	return &ExtendedKey{key: secret[:], chainCode: chainCode, private: true}, nil
+}
+
+// ParseExtendedKey parses a serialized xprv or xpub.
+func ParseExtendedKey(key string) (*ExtendedKey, error) {
+	decoded := base58.Decode(key)
+	if len(decoded) != serializedExtendedKeyLen+4 {
+		return nil, errors.New("invalid extended key length")
+	}
+	payload, checksum := decoded[:serializedExtendedKeyLen], decoded[serializedExtendedKeyLen:]
+	if !bytes.Equal(checksum, doubleSha256(payload)[:4]) {
+		return nil, errors.New("invalid extended key checksum")
+	}
+	k := &ExtendedKey{
+		depth:      payload[4],
+		childIndex: binary.BigEndian.Uint32(payload[9:13]),
+	}
+	copy(k.fingerprint[:], payload[5:9])
+	copy(k.chainCode[:], payload[13:45])
+	keyData := payload[45:]
+	switch {
+	case bytes.Equal(payload[:4], xprvVersion):
+		if keyData[0] != 0 {
+			return nil, errors.New("invalid extended private key")
+		}
+		var scalar btcec.ModNScalar
+		if overflow := scalar.SetByteSlice(keyData[1:]); overflow || scalar.IsZero() {
+			return nil, errors.New("invalid extended private key")
+		}
+		k.key = append([]byte(nil), keyData[1:]...)
+		k.private = true
+	case bytes.Equal(payload[:4], xpubVersion):
+		if _, err := btcec.ParsePubKey(keyData); err != nil {
+			return nil, fmt.Errorf("invalid extended public key: %v", err)
+		}
+		k.key = append([]byte(nil), keyData...)
+	default:
+		return nil, fmt.Errorf("unknown extended key version %x", payload[:4])
+	}
+	if k.depth == 0 && (k.fingerprint != [4]byte{} || k.childIndex != 0) {
+		return nil, errors.New("invalid extended key: master key with a parent")
+	}
+	return k, nil
+}
+
+// String returns the key serialized as xprv or xpub.
+func (k *ExtendedKey) String() string {
+	payload := make([]byte, 0, serializedExtendedKeyLen+4)
+	if k.private {
+		payload = append(payload, xprvVersion...)
+	} else {
+		payload = append(payload, xpubVersion...)
+	}
+	payload = append(payload, k.depth)
+	payload = append(payload, k.fingerprint[:]...)
+	payload = append(payload, uint32ToBytes(k.childIndex)...)
+	payload = append(payload, k.chainCode[:]...)
+	if k.private {
+		payload = append(payload, 0)
+	}
+	payload = append(payload, k.key...)
+	payload = append(payload, doubleSha256(payload)[:4]...)
+	return base58.Encode(payload)
+}
+
+// IsPrivate tells whether k is an extended private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private
+}
+
+// Neuter returns the extended public key of k.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	return &ExtendedKey{
+		key:         k.pubKeyBytes(),
+		chainCode:   k.chainCode,
+		depth:       k.depth,
+		fingerprint: k.fingerprint,
+		childIndex:  k.childIndex,
+	}
+}
+
+// Child derives the child index of k, hardened if harden is true. Hardened children
+// need an extended private key.
+func (k *ExtendedKey) Child(index uint32, harden bool) (*ExtendedKey, error) {
+	if index >= hardenedIndex {
+		return nil, fmt.Errorf("invalid BIP 32 index %d", index)
+	}
+	if k.depth == 255 {
+		return nil, errors.New("cannot derive beyond depth 255")
+	}
+	child := &ExtendedKey{
+		depth:      k.depth + 1,
+		childIndex: index,
+		private:    k.private,
+	}
+	copy(child.fingerprint[:], secp256k1.PubKeySecp256k1(k.pubKeyBytes()).Address()[:4])
+	if harden {
+		child.childIndex |= hardenedIndex
+	}
+	if k.private {
+		var key [32]byte
+		copy(key[:], k.key)
+		key, child.chainCode = derivePrivateKey(key, k.chainCode, index, harden)
+		child.key = key[:]
+		return child, nil
+	}
+	if harden {
+		return nil, errors.New("cannot derive a hardened child from an extended public key")
+	}
+	var tweak [32]byte
+	tweak, child.chainCode = i64(k.chainCode[:], append(append([]byte(nil), k.key...), uint32ToBytes(index)...))
+	var scalar btcec.ModNScalar
+	if overflow := scalar.SetBytes(&tweak); overflow != 0 {
+		return nil, fmt.Errorf("invalid child %d, use the next index", index)
+	}
+	parent, err := btcec.ParsePubKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	var tweakPoint, parentPoint, childPoint btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&scalar, &tweakPoint)
+	parent.AsJacobian(&parentPoint)
+	btcec.AddNonConst(&tweakPoint, &parentPoint, &childPoint)
+	if (childPoint.X.IsZero() && childPoint.Y.IsZero()) || childPoint.Z.IsZero() {
+		return nil, fmt.Errorf("invalid child %d, use the next index", index)
+	}
+	childPoint.ToAffine()
+	child.key = btcec.NewPublicKey(&childPoint.X, &childPoint.Y).SerializeCompressed()
+	return child, nil
+}
+
+// Derive follows a BIP 32 path relative to k, like "0/5" from an account key or
+// "44'/714'/0'" from the master key.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	derived := k
+	for _, part := range strings.Split(path, "/") {
+		idx, harden, err := parsePathIndex(part)
+		if err != nil {
+			return nil, err
+		}
+		if derived, err = derived.Child(idx, harden); err != nil {
+			return nil, err
+		}
+	}
+	return derived, nil
+}
+
+// PubKey returns the public key of k.
+func (k *ExtendedKey) PubKey() crypto.PubKey {
+	return secp256k1.PubKeySecp256k1(k.pubKeyBytes())
+}
+
+// Address returns the account address of k.
+func (k *ExtendedKey) Address() ctypes.AccAddress {
+	return ctypes.AccAddress(k.PubKey().Address())
+}
+
+// KeyManager returns the key manager of an extended private key.
+func (k *ExtendedKey) KeyManager() (KeyManager, error) {
+	if !k.private {
+		return nil, errors.New("an extended public key can't sign")
+	}
+	var key [32]byte
+	copy(key[:], k.key)
+	m := keyManager{}
+	m.setDerivedKey(key, "")
+	return &m, nil
+}
+
+func (k *ExtendedKey) pubKeyBytes() []byte {
+	if !k.private {
+		return k.key
+	}
+	_, pub := btcec.PrivKeyFromBytes(k.key)
+	return pub.SerializeCompressed()
+}
+
+func doubleSha256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
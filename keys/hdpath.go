@@ -20,6 +20,8 @@ const (
 	BIP44Prefix = "44'/714'/"
 	PartialPath = "0'/0/0"
 	FullPath    = BIP44Prefix + PartialPath
+
+	hardenedIndex = 0x80000000
 )
 
 // BIP44Params wraps BIP 44 params (5 level BIP 32 path).
@@ -95,31 +97,50 @@ func ComputeMastersFromSeed(seed []byte) (secret [32]byte, chainCode [32]byte) {
 // DerivePrivateKeyForPath derives the private key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
 func DerivePrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path string) ([32]byte, error) {
+	derivedKey, _, err := derivePath(privKeyBytes, chainCode, path)
+	return derivedKey, err
+}
+
+// derivePath is DerivePrivateKeyForPath which also returns the chain code of the derived key,
+// so that its children can be derived later on without starting over from the master key.
+func derivePath(privKeyBytes [32]byte, chainCode [32]byte, path string) ([32]byte, [32]byte, error) {
 	data := privKeyBytes
 	parts := strings.Split(path, "/")
 	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		// harden == private derivation, else public derivation:
-		if harden {
-			part = part[:len(part)-1]
-		}
-		idx, err := strconv.Atoi(part)
+		idx, harden, err := parsePathIndex(part)
 		if err != nil {
-			return [32]byte{}, fmt.Errorf("invalid BIP 32 path: %s", err)
-		}
-		if idx < 0 {
-			return [32]byte{}, errors.New("invalid BIP 32 path: index negative ot too large")
+			return [32]byte{}, [32]byte{}, err
 		}
-		data, chainCode = derivePrivateKey(data, chainCode, uint32(idx), harden)
+		data, chainCode = derivePrivateKey(data, chainCode, idx, harden)
 	}
 	var derivedKey [32]byte
 	n := copy(derivedKey[:], data[:])
 	if n != 32 || len(data) != 32 {
-		return [32]byte{}, fmt.Errorf("expected a (secp256k1) key of length 32, got length: %v", len(data))
+		return [32]byte{}, [32]byte{}, fmt.Errorf("expected a (secp256k1) key of length 32, got length: %v", len(data))
 	}
 
-	return derivedKey, nil
+	return derivedKey, chainCode, nil
+}
+
+// parsePathIndex parses one level of a BIP 32 path, like "0" or "44'".
+func parsePathIndex(part string) (uint32, bool, error) {
+	if part == "" {
+		return 0, false, errors.New("invalid BIP 32 path: empty index")
+	}
+	// do we have an apostrophe?
+	harden := part[len(part)-1:] == "'"
+	// harden == private derivation, else public derivation:
+	if harden {
+		part = part[:len(part)-1]
+	}
+	idx, err := strconv.Atoi(part)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid BIP 32 path: %s", err)
+	}
+	if idx < 0 || idx >= hardenedIndex {
+		return 0, false, errors.New("invalid BIP 32 path: index negative ot too large")
+	}
+	return uint32(idx), harden, nil
 }
 
 // derivePrivateKey derives the private key with index and chainCode.
@@ -0,0 +1,158 @@
+package keys
+
+import (
+	"fmt"
+	"sync"
+
+	ctypes "github.com/bnb-chain/go-sdk/common/types"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// Discover stops scanning an account, as recommended by BIP 44.
+const DefaultGapLimit = 20
+
+// AccountGetter fetches accounts from the chain, the rpc client is one.
+// It returns a nil account for addresses which never received any funds.
+type AccountGetter interface {
+	GetAccount(addr ctypes.AccAddress) (ctypes.Account, error)
+}
+
+// HDWallet derives the keys of a mnemonic along m / 44' / 714' / account' / 0 / address_index.
+// The seed is computed once, and the key of each account is kept, so deriving many
+// addresses of the same account is cheap. It is safe for concurrent use.
+type HDWallet struct {
+	mnemonic  string
+	masterKey [32]byte
+	chainCode [32]byte
+
+	mtx      sync.Mutex
+	accounts map[uint32]hdNode
+}
+
+type hdNode struct {
+	key       [32]byte
+	chainCode [32]byte
+}
+
+// HDAccount is an account of the wallet found by Discover.
+type HDAccount struct {
+	Account uint32
+	// Used are the address indexes of the account which exist on chain, in ascending order.
+	Used []uint32
+	// NextIndex is the first address index after the last used one.
+	NextIndex uint32
+}
+
+// NewHDWallet creates a wallet from a mnemonic and its BIP39 passphrase, which is
+// usually empty.
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	seed, err := mnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	masterKey, chainCode := ComputeMastersFromSeed(seed)
+	return &HDWallet{
+		mnemonic:  mnemonic,
+		masterKey: masterKey,
+		chainCode: chainCode,
+		accounts:  make(map[uint32]hdNode),
+	}, nil
+}
+
+// KeyManager returns the key manager of the address index of account.
+func (w *HDWallet) KeyManager(account, index uint32) (KeyManager, error) {
+	key, err := w.derive(account, index)
+	if err != nil {
+		return nil, err
+	}
+	k := keyManager{}
+	k.setDerivedKey(key, w.mnemonic)
+	return &k, nil
+}
+
+// Address returns the address of the address index of account.
+func (w *HDWallet) Address(account, index uint32) (ctypes.AccAddress, error) {
+	km, err := w.KeyManager(account, index)
+	if err != nil {
+		return nil, err
+	}
+	return km.GetAddr(), nil
+}
+
+// PathKeyManager returns the key manager of a custom path. Like NewMnemonicPathKeyManager,
+// "purpose' / coin_type'" is fixed as "44'/714'/" and keyPath is the rest part.
+func (w *HDWallet) PathKeyManager(keyPath string) (KeyManager, error) {
+	key, err := DerivePrivateKeyForPath(w.masterKey, w.chainCode, BIP44Prefix+keyPath)
+	if err != nil {
+		return nil, err
+	}
+	k := keyManager{}
+	k.setDerivedKey(key, w.mnemonic)
+	return &k, nil
+}
+
+// ExtendedKey returns the extended private key of a custom path, completed like in
+// PathKeyManager. The extended public key of an account, ExtendedKey("0'").Neuter(),
+// derives the addresses "0/address_index" of the account without the private keys.
+func (w *HDWallet) ExtendedKey(keyPath string) (*ExtendedKey, error) {
+	master := &ExtendedKey{key: w.masterKey[:], chainCode: w.chainCode, private: true}
+	return master.Derive(BIP44Prefix + keyPath)
+}
+
+// Discover finds the used accounts of the wallet following the account discovery of BIP 44:
+// the addresses of an account are scanned until gapLimit consecutive ones are unused, and
+// accounts are scanned until one has no used address. A gapLimit of 0 means DefaultGapLimit.
+func (w *HDWallet) Discover(getter AccountGetter, gapLimit uint32) ([]HDAccount, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	var accounts []HDAccount
+	for account := uint32(0); ; account++ {
+		found := HDAccount{Account: account}
+		for index, gap := uint32(0), uint32(0); gap < gapLimit; index++ {
+			addr, err := w.Address(account, index)
+			if err != nil {
+				return nil, err
+			}
+			acc, err := getter.GetAccount(addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get account %s of path %s: %v", addr, NewBinanceBIP44Params(account, index), err)
+			}
+			if acc == nil {
+				gap++
+				continue
+			}
+			gap = 0
+			found.Used = append(found.Used, index)
+			found.NextIndex = index + 1
+		}
+		if len(found.Used) == 0 {
+			return accounts, nil
+		}
+		accounts = append(accounts, found)
+	}
+}
+
+// derive returns the private key of the address index of account, deriving the
+// account node first if this is the first use of account.
+func (w *HDWallet) derive(account, index uint32) ([32]byte, error) {
+	if account >= hardenedIndex || index >= hardenedIndex {
+		return [32]byte{}, fmt.Errorf("invalid BIP 32 path: account %d or index %d too large", account, index)
+	}
+	w.mtx.Lock()
+	node, ok := w.accounts[account]
+	w.mtx.Unlock()
+	if !ok {
+		path := fmt.Sprintf("%d'/%d'/%d'/0", BIPPurpose, BIPCoinType, account)
+		key, chainCode, err := derivePath(w.masterKey, w.chainCode, path)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		node = hdNode{key: key, chainCode: chainCode}
+		w.mtx.Lock()
+		w.accounts[account] = node
+		w.mtx.Unlock()
+	}
+	key, _ := derivePrivateKey(node.key, node.chainCode, index, false)
+	return key, nil
+}
@@ -39,7 +39,7 @@ type KeyManager interface {
 
 func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
 	k := keyManager{}
-	err := k.recoveryFromMnemonic(mnemonic, FullPath)
+	err := k.recoveryFromMnemonic(mnemonic, defaultBIP39Passphrase, FullPath)
 	return &k, err
 }
 
@@ -47,7 +47,15 @@ func NewMnemonicKeyManager(mnemonic string) (KeyManager, error) {
 // "purpose' / coin_type'" is fixed as "44'/714'/", user can customize the rest part.
 func NewMnemonicPathKeyManager(mnemonic, keyPath string) (KeyManager, error) {
 	k := keyManager{}
-	err := k.recoveryFromMnemonic(mnemonic, BIP44Prefix+keyPath)
+	err := k.recoveryFromMnemonic(mnemonic, defaultBIP39Passphrase, BIP44Prefix+keyPath)
+	return &k, err
+}
+
+// NewMnemonicPassphraseKeyManager is NewMnemonicPathKeyManager for a mnemonic protected by a
+// BIP39 passphrase. The same mnemonic with another passphrase gives unrelated keys.
+func NewMnemonicPassphraseKeyManager(mnemonic, passphrase, keyPath string) (KeyManager, error) {
+	k := keyManager{}
+	err := k.recoveryFromMnemonic(mnemonic, passphrase, BIP44Prefix+keyPath)
 	return &k, err
 }
 
@@ -106,12 +114,8 @@ func NewKeyManager() (KeyManager, error) {
 	return NewMnemonicKeyManager(mnemonic)
 }
 
-func (m *keyManager) recoveryFromMnemonic(mnemonic, keyPath string) error {
-	words := strings.Split(mnemonic, " ")
-	if len(words) != 12 && len(words) != 24 {
-		return fmt.Errorf("mnemonic length should either be 12 or 24")
-	}
-	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, defaultBIP39Passphrase)
+func (m *keyManager) recoveryFromMnemonic(mnemonic, passphrase, keyPath string) error {
+	seed, err := mnemonicToSeed(mnemonic, passphrase)
 	if err != nil {
 		return err
 	}
@@ -121,15 +125,24 @@ func (m *keyManager) recoveryFromMnemonic(mnemonic, keyPath string) error {
 	if err != nil {
 		return err
 	}
-	priKey := secp256k1.PrivKeySecp256k1(derivedPriv[:])
-	addr := ctypes.AccAddress(priKey.PubKey().Address())
-	if err != nil {
-		return err
+	m.setDerivedKey(derivedPriv, mnemonic)
+	return nil
+}
+
+// mnemonicToSeed checks the mnemonic and returns its BIP39 seed.
+func mnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	words := strings.Split(mnemonic, " ")
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, fmt.Errorf("mnemonic length should be 12, 15, 18, 21 or 24")
 	}
-	m.addr = addr
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+func (m *keyManager) setDerivedKey(derivedPriv [32]byte, mnemonic string) {
+	priKey := secp256k1.PrivKeySecp256k1(derivedPriv[:])
+	m.addr = ctypes.AccAddress(priKey.PubKey().Address())
 	m.privKey = priKey
 	m.mnemonic = mnemonic
-	return nil
 }
 
 func (m *keyManager) recoveryFromKeyStore(keystoreFile string, auth string) error {
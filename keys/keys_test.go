@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/cosmos/go-bip39"
 	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/crypto"
 
 	ctypes "github.com/bnb-chain/go-sdk/common/types"
 	"github.com/bnb-chain/go-sdk/types/msg"
@@ -235,3 +238,198 @@ func TestExportAsMnemonicyError(t *testing.T) {
 	_, err = km.ExportAsMnemonic()
 	assert.Error(t, err)
 }
+
+func TestSignOfflineNoError(t *testing.T) {
+	test1KeyManager, err := NewMnemonicKeyManager("swift slam quote sail high remain mandate sample now stamp title among fiscal captain joy puppy ghost arrow attract ozone situate install gain mean")
+	assert.NoError(t, err)
+	test2KeyManager, err := NewMnemonicKeyManager("bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber")
+	assert.NoError(t, err)
+
+	coins := ctypes.Coins{ctypes.Coin{Denom: "BNB", Amount: 100000000000000}}
+	unsigned, err := tx.EncodeUnsignedTx(tx.StdSignMsg{
+		ChainID:       "bnbchain-1000",
+		AccountNumber: 0,
+		Sequence:      1,
+		Msgs:          []msg.Msg{msg.CreateSendMsg(test1KeyManager.GetAddr(), coins, []msg.Transfer{{test2KeyManager.GetAddr(), coins}})},
+	})
+	assert.NoError(t, err)
+
+	signed, err := SignOffline(unsigned, test1KeyManager)
+	assert.NoError(t, err)
+	signBz, err := tx.SignedTxBytes(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "c601f0625dee0a522a2c87fa0a250a141d0e3086e8e4e0a53c38a90d55bd58b34d57d2fa120d0a03424e42108080e983b1de1612250a146b571fc0a9961a7ddf45e49a88a4d83941fcabbe120d0a03424e42108080e983b1de16126c0a26eb5ae98721027e69d96640300433654e016d218a8d7ffed751023d8efe81e55dedbd6754c97112408b23eecfa8237a27676725173e58154e6c204bb291b31c3b7b507c8f04e2773909ba70e01b54f4bd0bc76669f5712a5a66b9508acdf3aa5e4fde75fbe57622a12001", hex.EncodeToString(signBz))
+
+	_, err = SignOffline(unsigned, test2KeyManager)
+	assert.Error(t, err)
+}
+
+func TestMultisigNoError(t *testing.T) {
+	var members []KeyManager
+	var pubkeys []crypto.PubKey
+	for i := 0; i < 3; i++ {
+		km, err := NewKeyManager()
+		assert.NoError(t, err)
+		members = append(members, km)
+		pubkeys = append(pubkeys, km.GetPrivKey().PubKey())
+	}
+	multisigKey, err := NewMultisigPubKey(2, pubkeys)
+	assert.NoError(t, err)
+	_, err = NewMultisigPubKey(4, pubkeys)
+	assert.Error(t, err)
+
+	from := MultisigAddress(multisigKey)
+	coins := ctypes.Coins{ctypes.Coin{Denom: "BNB", Amount: 100}}
+	signMsg := tx.StdSignMsg{
+		ChainID:       "bnbchain-1000",
+		AccountNumber: 5,
+		Sequence:      2,
+		Msgs:          []msg.Msg{msg.CreateSendMsg(from, coins, []msg.Transfer{{members[0].GetAddr(), coins}})},
+	}
+	sig0, err := SignPartial(signMsg, members[0])
+	assert.NoError(t, err)
+	sig2, err := SignPartial(signMsg, members[2])
+	assert.NoError(t, err)
+
+	_, err = CombineSignatures(signMsg, multisigKey, sig2)
+	assert.Error(t, err)
+
+	bz, err := CombineSignatures(signMsg, multisigKey, sig2, sig0)
+	assert.NoError(t, err)
+	var stdTx tx.StdTx
+	assert.NoError(t, tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx))
+	assert.Len(t, stdTx.Signatures, 1)
+	assert.Equal(t, from, ctypes.AccAddress(stdTx.Signatures[0].PubKey.Address()))
+	assert.True(t, stdTx.Signatures[0].PubKey.VerifyBytes(signMsg.Bytes(), stdTx.Signatures[0].Signature))
+}
+
+type accountsOnChain map[string]bool
+
+func (a accountsOnChain) GetAccount(addr ctypes.AccAddress) (ctypes.Account, error) {
+	if !a[addr.String()] {
+		return nil, nil
+	}
+	return &ctypes.AppAccount{BaseAccount: ctypes.BaseAccount{Address: addr}}, nil
+}
+
+func TestHDWalletNoError(t *testing.T) {
+	mnemonic := "bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber"
+	wallet, err := NewHDWallet(mnemonic, "")
+	assert.NoError(t, err)
+	addr, err := wallet.Address(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1ddt3ls9fjcd8mh69ujdg3fxc89qle2a7km33aa", addr.String())
+	customPathKey, err := wallet.PathKeyManager("1'/1/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1c67nwp7u5adl7gw0ffn3d47kttcm4crjy9mrye", customPathKey.GetAddr().String())
+	for _, index := range []uint32{0, 7} {
+		km, err := wallet.KeyManager(1, index)
+		assert.NoError(t, err)
+		expected, err := NewMnemonicPathKeyManager(mnemonic, fmt.Sprintf("1'/0/%d", index))
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetPrivKey(), km.GetPrivKey())
+	}
+	withPassphrase, err := NewHDWallet(mnemonic, "secret")
+	assert.NoError(t, err)
+	otherAddr, err := withPassphrase.Address(0, 0)
+	assert.NoError(t, err)
+	assert.NotEqual(t, addr, otherAddr)
+
+	used := accountsOnChain{}
+	for _, path := range [][2]uint32{{0, 0}, {0, 3}, {0, 9}, {1, 2}, {3, 0}} {
+		addr, err := wallet.Address(path[0], path[1])
+		assert.NoError(t, err)
+		used[addr.String()] = true
+	}
+	accounts, err := wallet.Discover(used, 5)
+	assert.NoError(t, err)
+	// index 9 is past the gap limit of account 0, account 3 is past the unused account 2
+	assert.Equal(t, []HDAccount{{Account: 0, Used: []uint32{0, 3}, NextIndex: 4}, {Account: 1, Used: []uint32{2}, NextIndex: 3}}, accounts)
+
+	_, err = wallet.KeyManager(0x80000000, 0)
+	assert.Error(t, err)
+	_, err = wallet.PathKeyManager("0'//0")
+	assert.Error(t, err)
+	_, err = NewHDWallet("bottom quick strong", "")
+	assert.Error(t, err)
+}
+
+func TestMnemonicPassphraseNoError(t *testing.T) {
+	// BIP39 test vector, the passphrase is TREZOR
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will"
+	seed, err := mnemonicToSeed(mnemonic, "TREZOR")
+	assert.NoError(t, err)
+	assert.Equal(t, "f2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd", hex.EncodeToString(seed))
+
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, err := bip39.NewEntropy(bits)
+		assert.NoError(t, err)
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		assert.NoError(t, err)
+		km, err := NewMnemonicPassphraseKeyManager(mnemonic, "", PartialPath)
+		assert.NoError(t, err)
+		expected, err := NewMnemonicKeyManager(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetAddr(), km.GetAddr())
+		protected, err := NewMnemonicPassphraseKeyManager(mnemonic, "secret", PartialPath)
+		assert.NoError(t, err)
+		assert.NotEqual(t, km.GetAddr(), protected.GetAddr())
+	}
+	_, err = NewMnemonicKeyManager("legal winner thank year wave sausage worth useful legal winner thank year wave sausage")
+	assert.Error(t, err)
+}
+
+func TestExtendedKeyNoError(t *testing.T) {
+	// BIP32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterExtendedKey(seed)
+	assert.NoError(t, err)
+	assert.Equal(t, "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", master.String())
+	assert.Equal(t, "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8", master.Neuter().String())
+	account, err := master.Derive("0'")
+	assert.NoError(t, err)
+	assert.Equal(t, "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7", account.String())
+	xpub := account.Neuter().String()
+	assert.Equal(t, "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw", xpub)
+	child, err := account.Derive("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs", child.String())
+
+	// public derivation gives the same keys as private derivation
+	watchOnly, err := ParseExtendedKey(xpub)
+	assert.NoError(t, err)
+	assert.False(t, watchOnly.IsPrivate())
+	publicChild, err := watchOnly.Derive("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ", publicChild.String())
+	_, err = watchOnly.Derive("1'")
+	assert.Error(t, err)
+	_, err = watchOnly.KeyManager()
+	assert.Error(t, err)
+	parsed, err := ParseExtendedKey(child.String())
+	assert.NoError(t, err)
+	assert.Equal(t, child, parsed)
+	_, err = ParseExtendedKey(xpub[:len(xpub)-1] + "x")
+	assert.Error(t, err)
+
+	mnemonic := "bottom quick strong ranch section decide pepper broken oven demand coin run jacket curious business achieve mule bamboo remain vote kid rigid bench rubber"
+	wallet, err := NewHDWallet(mnemonic, "")
+	assert.NoError(t, err)
+	accountKey, err := wallet.ExtendedKey("0'")
+	assert.NoError(t, err)
+	receiving, err := ParseExtendedKey(accountKey.Neuter().String())
+	assert.NoError(t, err)
+	for _, index := range []uint32{0, 1, 42} {
+		expected, err := wallet.KeyManager(0, index)
+		assert.NoError(t, err)
+		derived, err := receiving.Derive(fmt.Sprintf("0/%d", index))
+		assert.NoError(t, err)
+		assert.Equal(t, expected.GetAddr(), derived.Address())
+		assert.Equal(t, expected.GetPrivKey().PubKey(), derived.PubKey())
+	}
+	privChild, err := accountKey.Derive("0/0")
+	assert.NoError(t, err)
+	km, err := privChild.KeyManager()
+	assert.NoError(t, err)
+	assert.Equal(t, "bnb1ddt3ls9fjcd8mh69ujdg3fxc89qle2a7km33aa", km.GetAddr().String())
+}
@@ -0,0 +1,88 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	ctypes "github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// NewMultisigPubKey returns a pubkey which any threshold of pubkeys can sign for.
+// The order of pubkeys is part of the key, so every party must use the same order.
+func NewMultisigPubKey(threshold int, pubkeys []crypto.PubKey) (crypto.PubKey, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be positive, got %d", threshold)
+	}
+	if len(pubkeys) < threshold {
+		return nil, fmt.Errorf("threshold %d is larger than the number of pubkeys %d", threshold, len(pubkeys))
+	}
+	for i := range pubkeys {
+		for j := i + 1; j < len(pubkeys); j++ {
+			if pubkeys[i].Equals(pubkeys[j]) {
+				return nil, fmt.Errorf("duplicated pubkey at index %d and %d", i, j)
+			}
+		}
+	}
+	return multisig.NewPubKeyMultisigThreshold(threshold, pubkeys), nil
+}
+
+// MultisigAddress returns the account address owned by a multisig pubkey.
+func MultisigAddress(multisigKey crypto.PubKey) ctypes.AccAddress {
+	return ctypes.AccAddress(multisigKey.Address())
+}
+
+// SignPartial signs signMsg as one member of a multisig account. The signature can be
+// passed around as amino JSON with tx.Cdc until enough of them are collected.
+func SignPartial(signMsg tx.StdSignMsg, km KeyManager) (tx.StdSignature, error) {
+	bz, err := km.Sign(signMsg)
+	if err != nil {
+		return tx.StdSignature{}, err
+	}
+	var stdTx tx.StdTx
+	if err := tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx); err != nil {
+		return tx.StdSignature{}, err
+	}
+	if len(stdTx.Signatures) != 1 {
+		return tx.StdSignature{}, fmt.Errorf("expect exactly one signature, got %d", len(stdTx.Signatures))
+	}
+	return stdTx.Signatures[0], nil
+}
+
+// CombineSignatures assembles partial signatures from members of multisigKey into a
+// signed tx, encoded the same way as KeyManager.Sign and ready to be broadcast.
+func CombineSignatures(signMsg tx.StdSignMsg, multisigKey crypto.PubKey, sigs ...tx.StdSignature) ([]byte, error) {
+	thresholdKey, ok := multisigKey.(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a multisig pubkey", multisigKey)
+	}
+	signBytes := signMsg.Bytes()
+	mSig := multisig.NewMultisig(len(thresholdKey.PubKeys))
+	for _, sig := range sigs {
+		if sig.AccountNumber != signMsg.AccountNumber || sig.Sequence != signMsg.Sequence {
+			return nil, fmt.Errorf("signature of %s is for another account number or sequence", ctypes.AccAddress(sig.PubKey.Address()))
+		}
+		if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return nil, fmt.Errorf("invalid signature of %s", ctypes.AccAddress(sig.PubKey.Address()))
+		}
+		if err := mSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, thresholdKey.PubKeys); err != nil {
+			return nil, err
+		}
+	}
+	if len(mSig.Sigs) < int(thresholdKey.K) {
+		return nil, fmt.Errorf("got %d signatures, %d required", len(mSig.Sigs), thresholdKey.K)
+	}
+	sig := tx.StdSignature{
+		AccountNumber: signMsg.AccountNumber,
+		Sequence:      signMsg.Sequence,
+		PubKey:        multisigKey,
+		Signature:     mSig.Marshal(),
+	}
+	if !multisigKey.VerifyBytes(signBytes, sig.Signature) {
+		return nil, fmt.Errorf("combined signature verification failed")
+	}
+	newTx := tx.NewStdTx(signMsg.Msgs, []tx.StdSignature{sig}, signMsg.Memo, signMsg.Source, signMsg.Data)
+	return tx.Cdc.MarshalBinaryLengthPrefixed(&newTx)
+}
@@ -0,0 +1,39 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// SignOffline signs an unsigned tx produced by BuildUnsigned and returns it in the
+// SignedTx JSON format, ready for BroadcastSigned. It needs no network access.
+func SignOffline(unsigned []byte, km KeyManager) ([]byte, error) {
+	signMsg, err := tx.DecodeUnsignedTx(unsigned)
+	if err != nil {
+		return nil, err
+	}
+	isSigner := false
+	for _, m := range signMsg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		for _, signer := range m.GetSigners() {
+			if signer.Equals(km.GetAddr()) {
+				isSigner = true
+			}
+		}
+	}
+	if !isSigner {
+		return nil, fmt.Errorf("%s is not a signer of the tx", km.GetAddr())
+	}
+	bz, err := km.Sign(signMsg)
+	if err != nil {
+		return nil, err
+	}
+	var stdTx tx.StdTx
+	if err := tx.Cdc.UnmarshalBinaryLengthPrefixed(bz, &stdTx); err != nil {
+		return nil, err
+	}
+	return tx.EncodeSignedTx(stdTx)
+}
@@ -6,6 +6,7 @@ const (
 	DefaultAPIVersionPrefix = "/api/v1"
 	DefaultWSPrefix         = "/api/ws"
 	NativeSymbol            = "BNB"
+	MaxMemoLength           = 100
 
 	ProdChainID    = "Binance-Chain-Tigris"
 	TestnetChainID = "Binance-Chain-Ganges"
@@ -0,0 +1,181 @@
+package tx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Failure kinds to be matched with errors.Is against the errors returned by the clients.
+var (
+	ErrInternal          = errors.New("internal error")
+	ErrTxDecode          = errors.New("tx parse error")
+	ErrInvalidSequence   = errors.New("invalid sequence")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrUnknownRequest    = errors.New("unknown request")
+	ErrInvalidAddress    = errors.New("invalid address")
+	ErrInvalidPubKey     = errors.New("invalid pubkey")
+	ErrInvalidCoins      = errors.New("invalid coins")
+	ErrMemoTooLarge      = errors.New("memo too large")
+	ErrInsufficientFee   = errors.New("insufficient fee")
+	ErrUnknownToken      = errors.New("unknown token")
+	ErrNotFound          = errors.New("not found")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrUnavailable       = errors.New("service unavailable")
+)
+
+// Codespaces of the chain modules, see the DefaultCodespace of each module.
+const (
+	CodespaceRoot       uint16 = 1
+	CodespaceBank       uint16 = 2
+	CodespaceStake      uint16 = 4
+	CodespaceGov        uint16 = 5
+	CodespaceDex        uint16 = 6
+	CodespaceTimeLock   uint16 = 7
+	CodespaceAtomicSwap uint16 = 8
+	CodespaceOracle     uint16 = 11
+	CodespaceBridge     uint16 = 12
+	CodespaceSideChain  uint16 = 31
+)
+
+var rootCodeKinds = map[uint16]error{
+	1:  ErrInternal,
+	2:  ErrTxDecode,
+	3:  ErrInvalidSequence,
+	4:  ErrUnauthorized,
+	5:  ErrInsufficientFunds,
+	6:  ErrUnknownRequest,
+	7:  ErrInvalidAddress,
+	8:  ErrInvalidPubKey,
+	9:  ErrNotFound,
+	10: ErrInsufficientFunds,
+	11: ErrInvalidCoins,
+	12: ErrMemoTooLarge,
+	13: ErrInsufficientFee,
+}
+
+// moduleNotFoundCodes are the module codes meaning the queried record does not exist.
+var moduleNotFoundCodes = map[uint16][]uint16{
+	CodespaceGov:        {1},
+	CodespaceTimeLock:   {5, 8},
+	CodespaceAtomicSwap: {10},
+}
+
+// ABCIError is a failed CheckTx, DeliverTx or query, as returned by the node.
+type ABCIError struct {
+	// Code is the combined code, codespace << 16 | code within the codespace.
+	Code uint32
+	Log  string
+
+	kinds []error
+}
+
+// NewABCIError decodes the combined code of a failed response, it returns nil when code is 0.
+func NewABCIError(code uint32, log string) error {
+	if code == 0 {
+		return nil
+	}
+	e := &ABCIError{Code: code, Log: log}
+	if e.Codespace() == CodespaceRoot {
+		if kind, ok := rootCodeKinds[e.CodeInSpace()]; ok {
+			e.kinds = append(e.kinds, kind)
+		}
+	}
+	for _, c := range moduleNotFoundCodes[e.Codespace()] {
+		if c == e.CodeInSpace() {
+			e.kinds = append(e.kinds, ErrNotFound)
+		}
+	}
+	// tokens are not a codespace of their own, unknown symbols are reported in the log only
+	lower := strings.ToLower(log)
+	if strings.Contains(lower, "token") || strings.Contains(lower, "symbol") {
+		if strings.Contains(lower, "does not exist") || strings.Contains(lower, "not found") {
+			e.kinds = append(e.kinds, ErrUnknownToken, ErrNotFound)
+		}
+	}
+	return e
+}
+
+func (e *ABCIError) Codespace() uint16 {
+	return uint16(e.Code >> 16)
+}
+
+// CodeInSpace is the code within the codespace.
+func (e *ABCIError) CodeInSpace() uint16 {
+	return uint16(e.Code)
+}
+
+func (e *ABCIError) Error() string {
+	return fmt.Sprintf("codespace %d, code %d: %s", e.Codespace(), e.CodeInSpace(), e.Log)
+}
+
+func (e *ABCIError) Is(target error) bool {
+	for _, kind := range e.kinds {
+		if kind == target {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is a non 2xx response of the REST API. When the body reports a failed
+// tx or query, the decoded ABCIError is wrapped.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is how long the server asked to wait, on 429 responses.
+	RetryAfter time.Duration
+
+	abciErr error
+}
+
+func NewHTTPError(statusCode int, body []byte) *HTTPError {
+	e := &HTTPError{StatusCode: statusCode, Body: string(body)}
+	var resp struct {
+		Code     uint32 `json:"code"`
+		ABCICode uint32 `json:"abci_code"`
+		Message  string `json:"message"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return e
+	}
+	// broadcast failures nest the ABCI error in the message
+	var nested struct {
+		ABCICode uint32 `json:"abci_code"`
+		Message  string `json:"message"`
+	}
+	if json.Unmarshal([]byte(resp.Message), &nested) == nil && nested.ABCICode != 0 {
+		resp.ABCICode, resp.Message = nested.ABCICode, nested.Message
+	}
+	if resp.ABCICode == 0 && resp.Code > 0xFFFF {
+		resp.ABCICode = resp.Code
+	}
+	e.abciErr = NewABCIError(resp.ABCICode, resp.Message)
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("bad response, status code %d, response: %s", e.StatusCode, e.Body)
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.abciErr
+}
+
+func (e *HTTPError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return target == ErrUnavailable
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package tx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestABCIError(t *testing.T) {
+	assert.Nil(t, NewABCIError(0, ""))
+
+	err := NewABCIError(65539, "Invalid sequence. Got 3, expected 4")
+	assert.True(t, errors.Is(err, ErrInvalidSequence))
+	assert.False(t, errors.Is(err, ErrInsufficientFunds))
+	assert.True(t, IsInvalidSequenceError(fmt.Errorf("broadcast: %w", err)))
+
+	var abciErr *ABCIError
+	assert.True(t, errors.As(err, &abciErr))
+	assert.Equal(t, CodespaceRoot, abciErr.Codespace())
+	assert.Equal(t, uint16(3), abciErr.CodeInSpace())
+
+	assert.True(t, errors.Is(NewABCIError(65541, "insufficient account funds"), ErrInsufficientFunds))
+	assert.True(t, errors.Is(NewABCIError(458760, "unknown time lock"), ErrNotFound))
+	err = NewABCIError(65547, "symbol(XYZ-000) does not exist")
+	assert.True(t, errors.Is(err, ErrUnknownToken))
+	assert.True(t, errors.Is(err, ErrInvalidCoins))
+}
+
+func TestHTTPError(t *testing.T) {
+	err := NewHTTPError(429, []byte("too many requests"))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, "bad response, status code 429, response: too many requests", err.Error())
+	assert.True(t, errors.Is(NewHTTPError(404, nil), ErrNotFound))
+
+	body := `{"code":500,"failed_tx_index":0,"message":"{\"codespace\":1,\"code\":5,\"abci_code\":65541,\"message\":\"insufficient account funds\"}"}`
+	err = NewHTTPError(500, []byte(body))
+	assert.True(t, errors.Is(err, ErrInsufficientFunds))
+	var abciErr *ABCIError
+	assert.True(t, errors.As(err, &abciErr))
+	assert.Equal(t, "insufficient account funds", abciErr.Log)
+}
@@ -0,0 +1,168 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	cTypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+// relayFeeTypes are the fee params charged on top of the msg fee by cross chain msgs.
+var relayFeeTypes = map[string]string{
+	"crossBind":        "crossBindRelayFee",
+	"crossUnbind":      "crossUnbindRelayFee",
+	"crossTransferOut": "crossTransferOutRelayFee",
+}
+
+// MsgFee is the fee charged for a single msg, in the native token.
+type MsgFee struct {
+	MsgType  string                  `json:"msg_type"`
+	Fee      int64                   `json:"fee"`
+	RelayFee int64                   `json:"relay_fee,omitempty"`
+	FeeFor   types.FeeDistributeType `json:"fee_for"`
+}
+
+// FeeEstimate is the fee charged for a tx, in the native token.
+type FeeEstimate struct {
+	Msgs  []MsgFee `json:"msgs"`
+	Total int64    `json:"total"`
+}
+
+// EstimateFee computes the fee of every msg with the params returned by GetFee,
+// following the calculators registered by the chain. Mini and tiny token msgs have
+// their own msg types, and thus their own params.
+func EstimateFee(params []types.FeeParam, msgs ...msg.Msg) (*FeeEstimate, error) {
+	estimate := &FeeEstimate{Msgs: make([]MsgFee, 0, len(msgs))}
+	for _, m := range msgs {
+		fee, err := msgFee(params, m)
+		if err != nil {
+			return nil, err
+		}
+		if relayType, ok := relayFeeTypes[m.Type()]; ok {
+			relayFee, err := fixedFee(params, relayType)
+			if err != nil {
+				return nil, err
+			}
+			fee.RelayFee = relayFee.Fee
+		}
+		estimate.Msgs = append(estimate.Msgs, fee)
+		estimate.Total += fee.Fee + fee.RelayFee
+	}
+	return estimate, nil
+}
+
+// CalcFee sums the fee charged for msgs.
+func CalcFee(params []types.FeeParam, msgs ...msg.Msg) (int64, error) {
+	estimate, err := EstimateFee(params, msgs...)
+	if err != nil {
+		return 0, err
+	}
+	return estimate.Total, nil
+}
+
+func msgFee(params []types.FeeParam, m msg.Msg) (MsgFee, error) {
+	for _, p := range params {
+		if p, ok := p.(*types.TransferFeeParam); ok && p.MsgType == m.Type() {
+			return transferFee(p, m)
+		}
+	}
+	return fixedFee(params, m.Type())
+}
+
+func fixedFee(params []types.FeeParam, msgType string) (MsgFee, error) {
+	for _, p := range params {
+		if p, ok := p.(*types.FixedFeeParams); ok && p.MsgType == msgType {
+			if p.FeeFor == types.FeeFree || p.Fee <= 0 {
+				return MsgFee{MsgType: msgType, FeeFor: types.FeeFree}, nil
+			}
+			return MsgFee{MsgType: msgType, Fee: p.Fee, FeeFor: p.FeeFor}, nil
+		}
+	}
+	return MsgFee{}, fmt.Errorf("no fee param for msg type %s", msgType)
+}
+
+func transferFee(p *types.TransferFeeParam, m msg.Msg) (MsgFee, error) {
+	sendMsg, ok := m.(msg.SendMsg)
+	if !ok {
+		return MsgFee{}, fmt.Errorf("unexpected msg %T for transfer fee", m)
+	}
+	if p.FeeFor == types.FeeFree {
+		return MsgFee{MsgType: p.MsgType, FeeFor: types.FeeFree}, nil
+	}
+	var inputNum, outputNum int64
+	for _, input := range sendMsg.Inputs {
+		inputNum += int64(len(input.Coins))
+	}
+	for _, output := range sendMsg.Outputs {
+		outputNum += int64(len(output.Coins))
+	}
+	num := inputNum
+	if outputNum > num {
+		num = outputNum
+	}
+	fee := MsgFee{MsgType: p.MsgType, Fee: p.Fee, FeeFor: p.FeeFor}
+	if num >= p.LowerLimitAsMulti {
+		if p.MultiTransferFee == 0 {
+			fee.Fee = 0
+		} else if num > cTypes.TokenMaxTotalSupply/p.MultiTransferFee {
+			fee.Fee = cTypes.TokenMaxTotalSupply
+		} else {
+			fee.Fee = p.MultiTransferFee * num
+		}
+	}
+	return fee, nil
+}
+
+// FeeParamsFetcher loads the current fee params, e.g. rpc.HTTP.GetFeeCtx.
+type FeeParamsFetcher func(ctx context.Context) ([]types.FeeParam, error)
+
+// FeeCalculator estimates fees with params cached for refreshInterval.
+// It is safe for concurrent use.
+type FeeCalculator struct {
+	fetch           FeeParamsFetcher
+	refreshInterval time.Duration
+
+	mtx       sync.Mutex
+	params    []types.FeeParam
+	fetchedAt time.Time
+}
+
+func NewFeeCalculator(fetch FeeParamsFetcher, refreshInterval time.Duration) *FeeCalculator {
+	return &FeeCalculator{fetch: fetch, refreshInterval: refreshInterval}
+}
+
+// Params returns the cached params, fetching them again once they are older than the refresh interval.
+func (f *FeeCalculator) Params(ctx context.Context) ([]types.FeeParam, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.params != nil && time.Since(f.fetchedAt) < f.refreshInterval {
+		return f.params, nil
+	}
+	params, err := f.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f.params, f.fetchedAt = params, time.Now()
+	return params, nil
+}
+
+// Invalidate makes the next call fetch the params again, e.g. after a fee change proposal passed.
+func (f *FeeCalculator) Invalidate() {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.params = nil
+}
+
+// Estimate returns the fee of every msg and the total fee of a tx holding msgs.
+func (f *FeeCalculator) Estimate(ctx context.Context, msgs ...msg.Msg) (*FeeEstimate, error) {
+	params, err := f.Params(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return EstimateFee(params, msgs...)
+}
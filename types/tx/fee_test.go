@@ -0,0 +1,93 @@
+package tx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+func TestCalcFee(t *testing.T) {
+	from := types.AccAddress([]byte("fee-test-addr-from1"))
+	to := types.AccAddress([]byte("fee-test-addr-to-01"))
+	params := []types.FeeParam{
+		&types.TransferFeeParam{
+			FixedFeeParams:    types.FixedFeeParams{MsgType: "send", Fee: 37500, FeeFor: types.FeeForProposer},
+			MultiTransferFee:  30000,
+			LowerLimitAsMulti: 2,
+		},
+		&types.FixedFeeParams{MsgType: "timeLock", Fee: 1000000, FeeFor: types.FeeForProposer},
+	}
+	coins := types.Coins{{Denom: "BNB", Amount: 10}}
+
+	single := msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}})
+	fee, err := CalcFee(params, single)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(37500), fee)
+
+	multi := msg.CreateSendMsg(from, coins, []msg.Transfer{{ToAddr: to, Coins: coins}, {ToAddr: from, Coins: coins}})
+	lock := msg.NewTimeLockMsg(from, "lock", coins, 1000)
+	fee, err = CalcFee(params, multi, lock)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60000+1000000), fee)
+
+	_, err = CalcFee(params, msg.NewTimeUnlockMsg(from, 1))
+	assert.Error(t, err)
+
+	// no fee for multi transfers
+	params[0].(*types.TransferFeeParam).MultiTransferFee = 0
+	fee, err = CalcFee(params, multi)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), fee)
+}
+
+func TestEstimateFee(t *testing.T) {
+	from := types.AccAddress([]byte("fee-test-addr-from1"))
+	params := []types.FeeParam{
+		&types.FixedFeeParams{MsgType: "tinyIssueMsg", Fee: 200000000, FeeFor: types.FeeForAll},
+		&types.FixedFeeParams{MsgType: "miniIssueMsg", Fee: 400000000, FeeFor: types.FeeForAll},
+		&types.FixedFeeParams{MsgType: "vote", Fee: 100000000, FeeFor: types.FeeFree},
+		&types.FixedFeeParams{MsgType: "crossTransferOut", Fee: 1000, FeeFor: types.FeeForProposer},
+		&types.FixedFeeParams{MsgType: "crossTransferOutRelayFee", Fee: 2000, FeeFor: types.FeeForProposer},
+	}
+	msgs := []msg.Msg{
+		msg.NewTinyTokenIssueMsg(from, "tiny", "TNY", 10000000000, false, ""),
+		msg.NewMiniTokenIssueMsg(from, "mini", "MNI", 10000000000, false, ""),
+		msg.NewMsgVote(from, 1, msg.OptionYes),
+		msg.NewTransferOutMsg(from, msg.SmartChainAddress{}, types.Coin{Denom: "BNB", Amount: 1}, 0),
+	}
+	estimate, err := EstimateFee(params, msgs...)
+	assert.NoError(t, err)
+	assert.Len(t, estimate.Msgs, 4)
+	assert.Equal(t, int64(200000000), estimate.Msgs[0].Fee)
+	assert.Equal(t, int64(400000000), estimate.Msgs[1].Fee)
+	assert.Equal(t, int64(0), estimate.Msgs[2].Fee)
+	assert.Equal(t, types.FeeFree, estimate.Msgs[2].FeeFor)
+	assert.Equal(t, int64(2000), estimate.Msgs[3].RelayFee)
+	assert.Equal(t, int64(200000000+400000000+1000+2000), estimate.Total)
+}
+
+func TestFeeCalculatorRefresh(t *testing.T) {
+	fetches := 0
+	calc := NewFeeCalculator(func(ctx context.Context) ([]types.FeeParam, error) {
+		fetches++
+		return []types.FeeParam{&types.FixedFeeParams{MsgType: "timeUnlock", Fee: int64(fetches), FeeFor: types.FeeForProposer}}, nil
+	}, time.Hour)
+	unlock := msg.NewTimeUnlockMsg(types.AccAddress([]byte("fee-test-addr-from1")), 1)
+
+	estimate, err := calc.Estimate(context.Background(), unlock)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), estimate.Total)
+	estimate, err = calc.Estimate(context.Background(), unlock)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), estimate.Total)
+
+	calc.Invalidate()
+	estimate, err = calc.Estimate(context.Background(), unlock)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), estimate.Total)
+}
@@ -0,0 +1,54 @@
+package tx
+
+import (
+	"fmt"
+)
+
+// SignedTx is the JSON document carrying a signed tx from an offline signer
+// back to an online machine for broadcasting.
+type SignedTx struct {
+	Tx StdTx `json:"tx"`
+}
+
+// EncodeUnsignedTx serializes a StdSignMsg so it can be signed offline.
+func EncodeUnsignedTx(signMsg StdSignMsg) ([]byte, error) {
+	return Cdc.MarshalJSONIndent(signMsg, "", "  ")
+}
+
+// DecodeUnsignedTx parses the output of EncodeUnsignedTx.
+func DecodeUnsignedTx(bz []byte) (StdSignMsg, error) {
+	var signMsg StdSignMsg
+	if err := Cdc.UnmarshalJSON(bz, &signMsg); err != nil {
+		return signMsg, fmt.Errorf("invalid unsigned tx: %v", err)
+	}
+	if len(signMsg.Msgs) == 0 {
+		return signMsg, fmt.Errorf("invalid unsigned tx: no msg")
+	}
+	return signMsg, nil
+}
+
+// EncodeSignedTx serializes a signed tx into the SignedTx JSON format.
+func EncodeSignedTx(stdTx StdTx) ([]byte, error) {
+	return Cdc.MarshalJSONIndent(SignedTx{Tx: stdTx}, "", "  ")
+}
+
+// DecodeSignedTx parses the output of EncodeSignedTx.
+func DecodeSignedTx(bz []byte) (StdTx, error) {
+	var signed SignedTx
+	if err := Cdc.UnmarshalJSON(bz, &signed); err != nil {
+		return StdTx{}, fmt.Errorf("invalid signed tx: %v", err)
+	}
+	if len(signed.Tx.Signatures) == 0 {
+		return StdTx{}, fmt.Errorf("invalid signed tx: no signature")
+	}
+	return signed.Tx, nil
+}
+
+// SignedTxBytes converts a SignedTx JSON document into the amino bytes accepted by the chain.
+func SignedTxBytes(bz []byte) ([]byte, error) {
+	stdTx, err := DecodeSignedTx(bz)
+	if err != nil {
+		return nil, err
+	}
+	return Cdc.MarshalBinaryLengthPrefixed(&stdTx)
+}
@@ -0,0 +1,118 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccountFetcher loads the on-chain account number and sequence of addr.
+type AccountFetcher func(ctx context.Context, addr types.AccAddress) (accountNumber, sequence int64, err error)
+
+// SequenceManager hands out account numbers and sequences to sign with, so that
+// consecutive transactions from one key don't need an account query each.
+// Implementations must be safe for concurrent use.
+type SequenceManager interface {
+	// Next reserves a sequence for addr, calling fetch when nothing is cached.
+	Next(ctx context.Context, addr types.AccAddress, fetch AccountFetcher) (accountNumber, sequence int64, err error)
+	// Done reports the CheckTx outcome of a tx signed with a sequence returned by Next,
+	// err being nil when the tx was accepted.
+	Done(addr types.AccAddress, sequence int64, err error)
+	// Reset drops whatever is cached for addr.
+	Reset(addr types.AccAddress)
+}
+
+var expectedSequenceRe = regexp.MustCompile(`(?i)invalid sequence.*expected (\d+)`)
+
+// IsInvalidSequenceError reports whether err is the chain rejecting a tx for its sequence.
+func IsInvalidSequenceError(err error) bool {
+	if errors.Is(err, ErrInvalidSequence) {
+		return true
+	}
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "invalid sequence")
+}
+
+type sequenceEntry struct {
+	mtx           sync.Mutex
+	loaded        bool
+	accountNumber int64
+	next          int64
+}
+
+type localSequenceManager struct {
+	mtx     sync.Mutex
+	entries map[string]*sequenceEntry
+}
+
+// NewSequenceManager returns an in-memory SequenceManager. The sequence is bumped
+// locally on every accepted tx and resynchronised after an invalid sequence error.
+func NewSequenceManager() SequenceManager {
+	return &localSequenceManager{entries: make(map[string]*sequenceEntry)}
+}
+
+func (m *localSequenceManager) entry(addr types.AccAddress) *sequenceEntry {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e, ok := m.entries[addr.String()]
+	if !ok {
+		e = &sequenceEntry{}
+		m.entries[addr.String()] = e
+	}
+	return e
+}
+
+func (m *localSequenceManager) Next(ctx context.Context, addr types.AccAddress, fetch AccountFetcher) (int64, int64, error) {
+	e := m.entry(addr)
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if !e.loaded {
+		accountNumber, sequence, err := fetch(ctx, addr)
+		if err != nil {
+			return 0, 0, err
+		}
+		e.accountNumber, e.next, e.loaded = accountNumber, sequence, true
+	}
+	sequence := e.next
+	e.next++
+	return e.accountNumber, sequence, nil
+}
+
+func (m *localSequenceManager) Done(addr types.AccAddress, sequence int64, err error) {
+	if err == nil {
+		return
+	}
+	e := m.entry(addr)
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if !e.loaded {
+		return
+	}
+	if IsInvalidSequenceError(err) {
+		// the node tells which sequence it expects, otherwise query it again
+		if match := expectedSequenceRe.FindStringSubmatch(err.Error()); match != nil {
+			if expected, perr := strconv.ParseInt(match[1], 10, 64); perr == nil {
+				e.next = expected
+				return
+			}
+		}
+		e.loaded = false
+		return
+	}
+	// the sequence was not consumed; hand it out again if no later one was reserved
+	if sequence == e.next-1 {
+		e.next = sequence
+		return
+	}
+	e.loaded = false
+}
+
+func (m *localSequenceManager) Reset(addr types.AccAddress) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.entries, addr.String())
+}
@@ -0,0 +1,74 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceManagerConcurrentNext(t *testing.T) {
+	addr := types.AccAddress([]byte("sequence-test-addr-1"))
+	fetches := 0
+	fetch := func(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+		fetches++
+		return 7, 100, nil
+	}
+	m := NewSequenceManager()
+
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	seen := make(map[int64]bool)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accNum, seq, err := m.Next(context.Background(), addr, fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(7), accNum)
+			mtx.Lock()
+			seen[seq] = true
+			mtx.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, fetches)
+	assert.Len(t, seen, 50)
+	for seq := int64(100); seq < 150; seq++ {
+		assert.True(t, seen[seq])
+	}
+}
+
+func TestSequenceManagerDone(t *testing.T) {
+	addr := types.AccAddress([]byte("sequence-test-addr-2"))
+	fetches := 0
+	fetch := func(ctx context.Context, addr types.AccAddress) (int64, int64, error) {
+		fetches++
+		return 1, 10, nil
+	}
+	m := NewSequenceManager()
+
+	_, seq, _ := m.Next(context.Background(), addr, fetch)
+	m.Done(addr, seq, nil)
+	_, seq, _ = m.Next(context.Background(), addr, fetch)
+	assert.Equal(t, int64(11), seq)
+
+	// a rejected tx gives its sequence back
+	m.Done(addr, seq, errors.New("insufficient fund"))
+	_, seq, _ = m.Next(context.Background(), addr, fetch)
+	assert.Equal(t, int64(11), seq)
+
+	// the expected sequence is taken from the error
+	m.Done(addr, seq, errors.New("Invalid sequence. Got 11, expected 15"))
+	_, seq, _ = m.Next(context.Background(), addr, fetch)
+	assert.Equal(t, int64(15), seq)
+
+	// otherwise the account is queried again
+	m.Done(addr, seq, errors.New("invalid sequence"))
+	_, seq, _ = m.Next(context.Background(), addr, fetch)
+	assert.Equal(t, int64(10), seq)
+	assert.Equal(t, 2, fetches)
+}
@@ -0,0 +1,44 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+// NewSignMsg prepares the StdSignMsg of msgs on chainID and applies options to it.
+// The account number and sequence are -1 unless set by an option, in which case
+// the caller has to query them before signing.
+func NewSignMsg(chainID string, msgs []msg.Msg, options ...Option) *StdSignMsg {
+	signMsg := &StdSignMsg{
+		ChainID:       chainID,
+		AccountNumber: -1,
+		Sequence:      -1,
+		Memo:          "",
+		Msgs:          append([]msg.Msg{}, msgs...),
+		Source:        Source,
+	}
+
+	for _, op := range options {
+		signMsg = op(signMsg)
+	}
+	return signMsg
+}
+
+// SetOrderIds is the special logic for createOrder, to save account query: the id of
+// an order derives from the sequence of the tx and the sender. For that reason a tx
+// holds at most one CreateOrderMsg.
+func SetOrderIds(signMsg *StdSignMsg, sender types.AccAddress) error {
+	orders := 0
+	for i, m := range signMsg.Msgs {
+		if orderMsg, ok := m.(msg.CreateOrderMsg); ok {
+			if orders++; orders > 1 {
+				return fmt.Errorf("a tx can hold only one CreateOrderMsg")
+			}
+			orderMsg.Id = msg.GenerateOrderID(signMsg.Sequence+1, sender)
+			signMsg.Msgs[i] = orderMsg
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package tx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/msg"
+)
+
+func TestNewSignMsg(t *testing.T) {
+	sender := types.AccAddress("sign-msg-test-addr-1")
+	msgs := []msg.Msg{msg.CreateOrderMsg{Sender: sender}}
+	signMsg := NewSignMsg("test-chain", msgs, WithMemo("memo"), WithAcNumAndSequence(3, 5))
+	assert.Equal(t, "test-chain", signMsg.ChainID)
+	assert.Equal(t, "memo", signMsg.Memo)
+	assert.Equal(t, int64(3), signMsg.AccountNumber)
+	assert.Equal(t, int64(5), signMsg.Sequence)
+
+	assert.NoError(t, SetOrderIds(signMsg, sender))
+	assert.Equal(t, msg.GenerateOrderID(6, sender), signMsg.Msgs[0].(msg.CreateOrderMsg).Id)
+	// msgs is left untouched
+	assert.Equal(t, "", msgs[0].(msg.CreateOrderMsg).Id)
+
+	signMsg = NewSignMsg("test-chain", append(msgs, msgs[0]))
+	assert.Error(t, SetOrderIds(signMsg, sender))
+}
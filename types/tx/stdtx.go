@@ -8,6 +8,10 @@ import (
 
 const Source int64 = 0
 
+// MaxMsgs is the number of msgs accepted in a tx by the chain, which rejects
+// the txs holding more than one msg.
+const MaxMsgs = 1
+
 type (
 	Tx           = types.Tx
 	StdTx        = auth.StdTx
@@ -0,0 +1,140 @@
+// Package txdecode turns raw transactions into a JSON-friendly view for inspection.
+package txdecode
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/bnb-chain/go-sdk/common/types"
+	"github.com/bnb-chain/go-sdk/types/tx"
+)
+
+// Tx is the decoded view of a transaction. Its JSON encoding is stable.
+type Tx struct {
+	Hash       string      `json:"hash"`
+	ChainID    string      `json:"chain_id"`
+	Memo       string      `json:"memo"`
+	Source     int64       `json:"source"`
+	Data       string      `json:"data,omitempty"`
+	Msgs       []Msg       `json:"msgs"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// Msg describes one msg of a tx. Value is the amino JSON of the msg, so
+// addresses are bech32 encoded and coins are listed with their denom.
+type Msg struct {
+	Type    string          `json:"type"`
+	Route   string          `json:"route"`
+	Name    string          `json:"name"`
+	Signers []string        `json:"signers"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Signature describes one signature of a tx and whether it is valid for the expected signer.
+type Signature struct {
+	Signer        string `json:"signer"`
+	Address       string `json:"address"`
+	PubKey        string `json:"pub_key"`
+	AccountNumber int64  `json:"account_number"`
+	Sequence      int64  `json:"sequence"`
+	Valid         bool   `json:"valid"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Decode decodes the amino encoded tx and verifies its signatures with chainID.
+func Decode(txBytes []byte, chainID string) (*Tx, error) {
+	var stdTx tx.StdTx
+	if err := tx.Cdc.UnmarshalBinaryLengthPrefixed(txBytes, &stdTx); err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %v", err)
+	}
+	decoded := &Tx{
+		Hash:       strings.ToUpper(hex.EncodeToString(tmhash.Sum(txBytes))),
+		ChainID:    chainID,
+		Memo:       stdTx.Memo,
+		Source:     stdTx.Source,
+		Msgs:       make([]Msg, 0, len(stdTx.Msgs)),
+		Signatures: make([]Signature, 0, len(stdTx.Signatures)),
+	}
+	if len(stdTx.Data) > 0 {
+		decoded.Data = hex.EncodeToString(stdTx.Data)
+	}
+	for _, m := range stdTx.Msgs {
+		bz, err := tx.Cdc.MarshalJSON(m)
+		if err != nil {
+			return nil, err
+		}
+		var typed struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(bz, &typed); err != nil {
+			return nil, err
+		}
+		signers := make([]string, 0, len(m.GetSigners()))
+		for _, signer := range m.GetSigners() {
+			signers = append(signers, signer.String())
+		}
+		decoded.Msgs = append(decoded.Msgs, Msg{
+			Type:    m.Type(),
+			Route:   m.Route(),
+			Name:    typed.Type,
+			Signers: signers,
+			Value:   typed.Value,
+		})
+	}
+
+	signers := stdTx.GetSigners()
+	for i, sig := range stdTx.Signatures {
+		view := Signature{
+			AccountNumber: sig.AccountNumber,
+			Sequence:      sig.Sequence,
+		}
+		if i < len(signers) {
+			view.Signer = signers[i].String()
+		}
+		if sig.PubKey == nil {
+			view.Error = "missing pubkey"
+			decoded.Signatures = append(decoded.Signatures, view)
+			continue
+		}
+		view.Address = types.AccAddress(sig.PubKey.Address()).String()
+		view.PubKey = hex.EncodeToString(sig.PubKey.Bytes())
+		signBytes := tx.StdSignBytes(chainID, sig.AccountNumber, sig.Sequence, stdTx.Msgs, stdTx.Memo, stdTx.Source, stdTx.Data)
+		switch {
+		case i >= len(signers):
+			view.Error = "no signer for this signature"
+		case view.Address != view.Signer:
+			view.Error = "pubkey does not belong to the signer"
+		case !sig.PubKey.VerifyBytes(signBytes, sig.Signature):
+			view.Error = "signature verification failed"
+		default:
+			view.Valid = true
+		}
+		decoded.Signatures = append(decoded.Signatures, view)
+	}
+	return decoded, nil
+}
+
+// DecodeString decodes a hex (optionally 0x prefixed) or base64 encoded tx.
+func DecodeString(s string, chainID string) (*Tx, error) {
+	s = strings.TrimSpace(s)
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if bz, err := hex.DecodeString(trimmed); err == nil {
+		return Decode(bz, chainID)
+	}
+	bz, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("tx is neither hex nor base64 encoded")
+	}
+	return Decode(bz, chainID)
+}
+
+// JSON renders the view with indentation, as used in audit logs.
+func (t *Tx) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
@@ -0,0 +1,36 @@
+package txdecode
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// signed by keys.TestSignTxNoError for chain bnbchain-1000
+const sendTxHex = "c601f0625dee0a522a2c87fa0a250a141d0e3086e8e4e0a53c38a90d55bd58b34d57d2fa120d0a03424e42108080e983b1de1612250a146b571fc0a9961a7ddf45e49a88a4d83941fcabbe120d0a03424e42108080e983b1de16126c0a26eb5ae98721027e69d96640300433654e016d218a8d7ffed751023d8efe81e55dedbd6754c97112408b23eecfa8237a27676725173e58154e6c204bb291b31c3b7b507c8f04e2773909ba70e01b54f4bd0bc76669f5712a5a66b9508acdf3aa5e4fde75fbe57622a12001"
+
+func TestDecodeString(t *testing.T) {
+	decoded, err := DecodeString(sendTxHex, "bnbchain-1000")
+	assert.NoError(t, err)
+	assert.Len(t, decoded.Msgs, 1)
+	assert.Equal(t, "send", decoded.Msgs[0].Type)
+	assert.Equal(t, "cosmos-sdk/Send", decoded.Msgs[0].Name)
+	assert.Len(t, decoded.Signatures, 1)
+	assert.True(t, decoded.Signatures[0].Valid)
+	assert.Equal(t, int64(1), decoded.Signatures[0].Sequence)
+	assert.Equal(t, decoded.Msgs[0].Signers[0], decoded.Signatures[0].Signer)
+
+	bz, _ := hex.DecodeString(sendTxHex)
+	fromBase64, err := DecodeString(base64.StdEncoding.EncodeToString(bz), "bnbchain-1000")
+	assert.NoError(t, err)
+	assert.Equal(t, decoded.Hash, fromBase64.Hash)
+
+	otherChain, err := Decode(bz, "Binance-Chain-Tigris")
+	assert.NoError(t, err)
+	assert.False(t, otherChain.Signatures[0].Valid)
+
+	_, err = DecodeString("not a tx", "bnbchain-1000")
+	assert.Error(t, err)
+}